@@ -3,14 +3,11 @@ package gogym
 import (
 	"fmt"
 	"math"
-	"time"
 
 	"golang.org/x/exp/rand"
 
 	python "github.com/DataDog/go-python3"
 	"gonum.org/v1/gonum/mat"
-	"gonum.org/v1/gonum/spatial/r1"
-	"gonum.org/v1/gonum/stat/distmv"
 )
 
 // BoxSpace represents a (possibly unbounded) box in R^n. Specifically, a
@@ -18,19 +15,25 @@ import (
 // interval has the form of one of [a, b], (-∞, b], [a, ∞), or
 // (-∞, ∞) for a, b ϵ R.
 //
-// The BoxSpace space must be constructed from its Python equivalent.
+// A BoxSpace can be constructed either from its Python equivalent
+// using NewBoxSpace or directly in Go using NewBox. If constructed in
+// Go, the embedded Python space is nil.
 type BoxSpace struct {
-	*python.PyObject // BoxSpace Space
-	rng              *distmv.Uniform
+	*python.PyObject // BoxSpace Space, nil if constructed in Go
+	rng              *rand.Rand
 	rand.Source
 	low, high                  *mat.VecDense
 	shape                      []int
 	boundedBelow, boundedAbove []bool
+	dtype                      DType
 }
 
 // NewBoxSpace takes a Python gym.spaces.BoxSpace and converts it into its Go
 // counterpart.
 func NewBoxSpace(space *python.PyObject) (Space, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("newBoxSpace: %v", err)
+	}
 	if !(space.Type() == boxSpace) {
 		return nil, fmt.Errorf("newBoxSpace: space is not a box space")
 	}
@@ -54,7 +57,12 @@ func NewBoxSpace(space *python.PyObject) (Space, error) {
 		return nil, fmt.Errorf("newBoxSpace: space %v is not a BoxSpace",
 			space.Type())
 	}
-	goLow, err := F64SliceFromIter(low)
+	flatLow := low.CallMethodArgs("flatten")
+	defer flatLow.DecRef()
+	if flatLow == nil {
+		return nil, fmt.Errorf("newBoxSpace: could not flatten lower bound")
+	}
+	goLow, err := F64SliceFromIter(flatLow)
 	if err != nil {
 		return nil, fmt.Errorf("newBoxSpace: could not compute lower bound: %v",
 			err)
@@ -67,32 +75,119 @@ func NewBoxSpace(space *python.PyObject) (Space, error) {
 		return nil, fmt.Errorf("newBoxSpace: space %v is not a BoxSpace",
 			space.Type())
 	}
-	goHigh, err := F64SliceFromIter(high)
+	flatHigh := high.CallMethodArgs("flatten")
+	defer flatHigh.DecRef()
+	if flatHigh == nil {
+		return nil, fmt.Errorf("newBoxSpace: could not flatten upper bound")
+	}
+	goHigh, err := F64SliceFromIter(flatHigh)
 	if err != nil {
 		return nil, fmt.Errorf("newBoxSpace: could not compute upper bound: %v",
 			err)
 	}
 
-	boundedBelow := make([]bool, len(goLow))
+	// Data type
+	dtype := space.GetAttrString("dtype")
+	defer dtype.DecRef()
+	if dtype == nil {
+		return nil, fmt.Errorf("newBoxSpace: space %v is not a BoxSpace",
+			space.Type())
+	}
+	name := dtype.GetAttrString("name")
+	defer name.DecRef()
+	if name == nil || !python.PyUnicode_Check(name) {
+		return nil, fmt.Errorf("newBoxSpace: could not get dtype name")
+	}
+	goDType, err := ParseDType(python.PyUnicode_AsUTF8(name))
+	if err != nil {
+		return nil, fmt.Errorf("newBoxSpace: %v", err)
+	}
+
+	box, err := NewTypedBox(goLow, goHigh, goShape, goDType)
+	if err != nil {
+		return nil, fmt.Errorf("newBoxSpace: %v", err)
+	}
+	box.PyObject = space
+
+	return box, nil
+}
+
+// NewBox returns a new BoxSpace with the given lower and upper bounds
+// and shape, without requiring a Python gym.spaces.Box. The bounds are
+// given in row-major order and must each have prod(shape) elements. If
+// shape is nil, the BoxSpace is taken to be one-dimensional. The
+// returned BoxSpace has the Float64 data type.
+func NewBox(low, high []float64, shape []int) (*BoxSpace, error) {
+	return NewTypedBox(low, high, shape, Float64)
+}
+
+// NewTypedBox is like NewBox, but returns a BoxSpace with the given
+// data type. The bounds are converted to the data type, and a
+// BoxSpace with an integer data type must have finite bounds.
+func NewTypedBox(low, high []float64, shape []int,
+	dtype DType) (*BoxSpace, error) {
+	if _, err := ParseDType(string(dtype)); err != nil {
+		return nil, fmt.Errorf("newTypedBox: %v", err)
+	}
+	if len(low) != len(high) {
+		return nil, fmt.Errorf("newTypedBox: low and high must have the "+
+			"same length \n\twant(%v) \n\thave(%v)", len(low), len(high))
+	}
+	if shape == nil {
+		shape = []int{len(low)}
+	}
+
+	size := 1
+	for _, dim := range shape {
+		if dim <= 0 {
+			return nil, fmt.Errorf("newTypedBox: shape %v must have only "+
+				"positive dimensions", shape)
+		}
+		size *= dim
+	}
+	if size != len(low) {
+		return nil, fmt.Errorf("newTypedBox: bounds of length %v do not "+
+			"match shape %v", len(low), shape)
+	}
+
+	for i := range low {
+		if low[i] > high[i] {
+			return nil, fmt.Errorf("newTypedBox: lower bound %v greater "+
+				"than upper bound %v at index %v", low[i], high[i], i)
+		}
+		if dtype.Integer() && (math.IsInf(low[i], 0) ||
+			math.IsInf(high[i], 0)) {
+			return nil, fmt.Errorf("newTypedBox: bounds of %v BoxSpace "+
+				"must be finite", dtype)
+		}
+	}
+
+	boundedBelow := make([]bool, len(low))
 	for i := range boundedBelow {
-		boundedBelow[i] = math.Inf(-1) < goLow[i]
+		boundedBelow[i] = math.Inf(-1) < low[i]
 	}
 
-	boundedAbove := make([]bool, len(goHigh))
+	boundedAbove := make([]bool, len(high))
 	for i := range boundedAbove {
-		boundedAbove[i] = math.Inf(1) > goHigh[i]
+		boundedAbove[i] = math.Inf(1) > high[i]
 	}
 
-	// Random number generator for sampling from the space
-	src := rand.NewSource(uint64(time.Now().UnixNano()))
-	bounds := make([]r1.Interval, len(goLow))
-	for i := range bounds {
-		bounds[i] = r1.Interval{Min: goLow[i], Max: goHigh[i]}
+	// Convert the bounds to the data type, which also ensures that the
+	// BoxSpace does not alias its arguments
+	goLow := make([]float64, len(low))
+	goHigh := make([]float64, len(high))
+	for i := range low {
+		goLow[i] = dtype.Convert(low[i])
+		goHigh[i] = dtype.Convert(high[i])
 	}
-	rng := distmv.NewUniform(bounds, src)
+	goShape := make([]int, len(shape))
+	copy(goShape, shape)
+
+	// Random number generator for sampling from the space
+	src := rand.NewSource(newSeed())
+	rng := rand.New(src)
 
 	return &BoxSpace{
-		PyObject:     space,
 		low:          mat.NewVecDense(len(goLow), goLow),
 		high:         mat.NewVecDense(len(goHigh), goHigh),
 		shape:        goShape,
@@ -100,17 +195,68 @@ func NewBoxSpace(space *python.PyObject) (Space, error) {
 		Source:       src,
 		boundedBelow: boundedBelow,
 		boundedAbove: boundedAbove,
+		dtype:        dtype,
 	}, nil
 }
 
-// Sample takes a sample from within the space bounds
+// Sample takes a sample from within the space bounds. Following
+// gym.spaces.Box, each dimension is sampled according to the form of
+// its interval:
+//
+//	Interval		Distribution
+//	[a, b]			Uniform(a, b)
+//	[a, ∞)			a + Exponential(1)
+//	(-∞, b]			b - Exponential(1)
+//	(-∞, ∞)			Normal(0, 1)
+//
+// If the BoxSpace has an integer data type, then the upper bound of
+// bounded dimensions is increased by one and all samples are rounded
+// down so that each integer in [a, b] is sampled with equal
+// probability. Otherwise, samples are rounded to the precision of the
+// data type.
 func (b *BoxSpace) Sample() []*mat.VecDense {
-	sample := b.rng.Rand(nil)
+	low := b.low.RawVector().Data
+	high := b.high.RawVector().Data
+
+	sample := make([]float64, len(low))
+	for i := range sample {
+		switch {
+		case b.boundedBelow[i] && b.boundedAbove[i]:
+			upper := high[i]
+			if b.dtype.Integer() {
+				upper = math.Floor(upper) + 1
+			}
+			sample[i] = low[i] + b.rng.Float64()*(upper-low[i])
+
+		case b.boundedBelow[i]:
+			sample[i] = low[i] + b.rng.ExpFloat64()
+
+		case b.boundedAbove[i]:
+			sample[i] = high[i] - b.rng.ExpFloat64()
+
+		default:
+			sample[i] = b.rng.NormFloat64()
+		}
+
+		if b.dtype.Integer() {
+			sample[i] = math.Min(math.Floor(sample[i]), high[i])
+		} else {
+			sample[i] = b.dtype.Convert(sample[i])
+		}
+	}
+
 	return []*mat.VecDense{mat.NewVecDense(len(sample), sample)}
 }
 
+// SamplePoint takes a sample from within the space bounds and returns
+// it as a *mat.VecDense
+func (b *BoxSpace) SamplePoint() interface{} {
+	return b.Sample()[0]
+}
+
 // Contains returns whether in is in the space. The argument in must
-// be either a []float64 or *mat.VecDense
+// be either a []float64 or *mat.VecDense. If the BoxSpace has an
+// integer data type, then each element of in must be an integer.
 func (b *BoxSpace) Contains(in interface{}) bool {
 	x, ok := in.([]float64)
 	if !ok {
@@ -128,6 +274,9 @@ func (b *BoxSpace) Contains(in interface{}) bool {
 		if x[i] < b.Low()[0].AtVec(i) || x[i] > b.High()[0].AtVec(i) {
 			return false
 		}
+		if !b.dtype.Contains(x[i]) {
+			return false
+		}
 	}
 	return true
 }
@@ -152,12 +301,116 @@ func (b *BoxSpace) BoundedBelow() []bool {
 	return b.boundedBelow
 }
 
+// Shape returns the shape of the space
+func (b *BoxSpace) Shape() []int {
+	return b.shape
+}
+
+// DType returns the data type of the space
+func (b *BoxSpace) DType() DType {
+	return b.dtype
+}
+
+// toPython converts the BoxSpace to a Python gym.spaces.Box. Creates a
+// new python.PyObject reference.
+func (b *BoxSpace) toPython() (*python.PyObject, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("toPython: %v", err)
+	}
+	low, err := b.ndarray(b.low.RawVector().Data)
+	if err != nil {
+		return nil, fmt.Errorf("toPython: could not convert lower bound: %v",
+			err)
+	}
+	defer low.DecRef()
+
+	high, err := b.ndarray(b.high.RawVector().Data)
+	if err != nil {
+		return nil, fmt.Errorf("toPython: could not convert upper bound: %v",
+			err)
+	}
+	defer high.DecRef()
+
+	args := python.PyTuple_New(2)
+	defer args.DecRef()
+	low.IncRef()
+	python.PyTuple_SetItem(args, 0, low)
+	high.IncRef()
+	python.PyTuple_SetItem(args, 1, high)
+
+	kwargs := python.PyDict_New()
+	defer kwargs.DecRef()
+	dtype := python.PyUnicode_FromString(b.dtype.String())
+	defer dtype.DecRef()
+	python.PyDict_SetItemString(kwargs, "dtype", dtype)
+
+	space := boxSpace.Call(args, kwargs)
+	if space == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("toPython: could not construct gym.spaces.Box")
+	}
+	return space, nil
+}
+
+// ndarray converts data to a NumPy ndarray with the shape and data
+// type of the BoxSpace. Creates a new python.PyObject reference.
+func (b *BoxSpace) ndarray(data []float64) (*python.PyObject, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("ndarray: %v", err)
+	}
+	list, err := F64ToList(data)
+	if err != nil {
+		return nil, fmt.Errorf("ndarray: %v", err)
+	}
+	defer list.DecRef()
+
+	shape, err := IntSliceToTuple(b.shape)
+	if err != nil {
+		return nil, fmt.Errorf("ndarray: %v", err)
+	}
+	defer shape.DecRef()
+
+	arr := numpy.CallMethodArgs("array", list)
+	defer arr.DecRef()
+	if arr == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("ndarray: could not construct numpy.ndarray")
+	}
+
+	reshaped := arr.CallMethodArgs("reshape", shape)
+	defer reshaped.DecRef()
+	if reshaped == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("ndarray: could not reshape to %v", b.shape)
+	}
+
+	dtype := python.PyUnicode_FromString(b.dtype.String())
+	defer dtype.DecRef()
+	typed := reshaped.CallMethodArgs("astype", dtype)
+	if typed == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("ndarray: could not convert to %v", b.dtype)
+	}
+	return typed, nil
+}
+
 // StackBefore stacks a BoxSpace to be the same shape as an argument
 // Python space. The argument space should be a stacked version of
 // the receiving BoxSpace's Python space, which is usually achieved
 // through some wrapper function. The newly added dimension will be
 // the first dimension.
 func (b *BoxSpace) StackBefore(space *python.PyObject) (*BoxSpace, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("stackBefore: %v", err)
+	}
 	if space.Type() != boxSpace {
 		return nil, fmt.Errorf("stackBefore: space must be a BoxSpace")
 	}
@@ -179,31 +432,41 @@ func (b *BoxSpace) StackBefore(space *python.PyObject) (*BoxSpace, error) {
 			"the receiving space")
 	}
 
-	newHigh := make([]float64, 0, totalComponents*shape[0])
-	newLow := make([]float64, 0, totalComponents*shape[0])
+	newBox, err := b.stack(shape)
+	if err != nil {
+		return nil, fmt.Errorf("stackBefore: %v", err)
+	}
+	newBox.PyObject = space
+
+	return newBox, nil
+}
+
+// Stack returns a new BoxSpace which stacks k copies of the receiving
+// space along a new leading axis, without requiring a Python space.
+// The returned space has shape (k, shape...), and each of its points
+// is k stacked points from the receiving space.
+func (b *BoxSpace) Stack(k int) (*BoxSpace, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("stack: k must be positive, got %v", k)
+	}
+
+	shape := append([]int{k}, b.shape...)
+	newBox, err := b.stack(shape)
+	if err != nil {
+		return nil, fmt.Errorf("stack: %v", err)
+	}
+	return newBox, nil
+}
+
+// stack returns a new BoxSpace of the given shape which stacks
+// shape[0] copies of the receiving space
+func (b *BoxSpace) stack(shape []int) (*BoxSpace, error) {
+	newHigh := make([]float64, 0, b.high.Len()*shape[0])
+	newLow := make([]float64, 0, b.low.Len()*shape[0])
 	for i := 0; i < shape[0]; i++ {
 		newHigh = append(newHigh, b.high.RawVector().Data...)
 		newLow = append(newLow, b.low.RawVector().Data...)
 	}
 
-	// Random number generator for sampling from the space
-	src := rand.NewSource(uint64(time.Now().UnixNano()))
-	bounds := make([]r1.Interval, len(newLow))
-	for i := range bounds {
-		bounds[i] = r1.Interval{Min: newLow[i], Max: newHigh[i]}
-	}
-	rng := distmv.NewUniform(bounds, src)
-
-	newBox := BoxSpace{
-		PyObject:     space,
-		low:          mat.NewVecDense(len(newLow), newLow),
-		high:         mat.NewVecDense(len(newHigh), newHigh),
-		shape:        shape,
-		rng:          rng,
-		Source:       src,
-		boundedBelow: b.boundedBelow,
-		boundedAbove: b.boundedAbove,
-	}
-
-	return &newBox, nil
+	return NewTypedBox(newLow, newHigh, shape, b.dtype)
 }
@@ -0,0 +1,308 @@
+package gogym
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// checkSteps is the number of steps taken in each trajectory generated
+// by CheckEnv
+const checkSteps = 100
+
+// checkSeed is the seed used by CheckEnv to check that seeding an
+// Environment makes its trajectories reproducible
+const checkSeed = 42
+
+// CheckReport is the result of checking an Environment with CheckEnv.
+// Errors are violations of the Environment API which will likely
+// break agents or wrappers, and warnings are properties of the
+// Environment which are legal but may cause problems.
+type CheckReport struct {
+	Name     string
+	Errors   []string
+	Warnings []string
+}
+
+// Passed returns whether the check found no errors
+func (c *CheckReport) Passed() bool {
+	return len(c.Errors) == 0
+}
+
+// Err returns an error listing all errors found by the check, or nil
+// if the check passed
+func (c *CheckReport) Err() error {
+	if c.Passed() {
+		return nil
+	}
+	return fmt.Errorf("checkEnv: %v failed with %v error(s): %v", c.Name,
+		len(c.Errors), strings.Join(c.Errors, "; "))
+}
+
+// String returns a human-readable version of the report
+func (c *CheckReport) String() string {
+	var b strings.Builder
+	status := "passed"
+	if !c.Passed() {
+		status = "failed"
+	}
+	fmt.Fprintf(&b, "%v: %v with %v error(s) and %v warning(s)\n", c.Name,
+		status, len(c.Errors), len(c.Warnings))
+	for _, err := range c.Errors {
+		fmt.Fprintf(&b, "\tERROR: %v\n", err)
+	}
+	for _, warning := range c.Warnings {
+		fmt.Fprintf(&b, "\tWARNING: %v\n", warning)
+	}
+	return b.String()
+}
+
+// errorf adds an error to the report
+func (c *CheckReport) errorf(format string, args ...interface{}) {
+	c.Errors = append(c.Errors, fmt.Sprintf(format, args...))
+}
+
+// warnf adds a warning to the report
+func (c *CheckReport) warnf(format string, args ...interface{}) {
+	c.Warnings = append(c.Warnings, fmt.Sprintf(format, args...))
+}
+
+// CheckEnv checks that env satisfies the Environment API and is
+// consistent with its spaces, similar to gym.utils.env_checker. It
+// checks that:
+//
+//   - The action and observation spaces are not nil
+//   - Observations returned by Reset and Step are in the observation
+//     space
+//   - Actions sampled from the action space are accepted by Step
+//   - Seeding env with the same seed and taking the same actions
+//     reproduces the same trajectory
+//   - Close can be called more than once
+//
+// Warnings are given for unbounded BoxSpaces and non-finite rewards.
+// Panics in env are recovered and reported as errors.
+//
+// CheckEnv steps through env and closes it, so env should not be used
+// after it is checked.
+func CheckEnv(env Environment) *CheckReport {
+	report := &CheckReport{Name: env.Name()}
+
+	actionSpace := env.ActionSpace()
+	if actionSpace == nil {
+		report.errorf("action space is nil")
+	} else {
+		checkBounded(report, "action", actionSpace)
+	}
+	observationSpace := env.ObservationSpace()
+	if observationSpace == nil {
+		report.errorf("observation space is nil")
+	} else {
+		checkBounded(report, "observation", observationSpace)
+	}
+
+	if actionSpace != nil && observationSpace != nil {
+		checkTrajectories(report, env)
+	}
+
+	checkClose(report, env)
+	return report
+}
+
+// checkBounded adds a warning to report for each BoxSpace in space
+// which is unbounded
+func checkBounded(report *CheckReport, name string, space Space) {
+	switch s := space.(type) {
+	case *BoxSpace:
+		below, above := s.BoundedBelow(), s.BoundedAbove()
+		for i := range below {
+			if !below[i] || !above[i] {
+				report.warnf("%v space is unbounded, which may cause "+
+					"problems for wrappers or agents that rely on its "+
+					"bounds", name)
+				return
+			}
+		}
+
+	case *TupleSpace:
+		for i := 0; i < s.Len(); i++ {
+			checkBounded(report, fmt.Sprintf("%v[%v]", name, i), s.At(i))
+		}
+
+	case *DictSpace:
+		for i, key := range s.keys {
+			checkBounded(report, fmt.Sprintf("%v[%v]", name, key),
+				s.values[i])
+		}
+	}
+}
+
+// trajectory is a sequence of observations and rewards
+type trajectory struct {
+	observations []*mat.VecDense
+	rewards      []float64
+}
+
+// checkTrajectories generates two trajectories in env with the same
+// seed and actions, checking the observations, actions, and rewards of
+// each, and checks that the trajectories are the same
+func checkTrajectories(report *CheckReport, env Environment) {
+	first, actions, ok := generate(report, env, nil)
+	if !ok {
+		return
+	}
+
+	// Only check the observations and rewards of the second trajectory
+	// for reproducibility
+	replay := &CheckReport{}
+	second, _, ok := generate(replay, env, actions)
+	if !ok {
+		report.errorf("seeded trajectory could not be replayed: %v",
+			strings.Join(replay.Errors, "; "))
+		return
+	}
+
+	if len(first.rewards) != len(second.rewards) {
+		report.errorf("seeding does not reproduce trajectories: episode "+
+			"lengths differ, %v and %v", len(first.rewards),
+			len(second.rewards))
+		return
+	}
+	for i := range first.observations {
+		a, b := first.observations[i], second.observations[i]
+		if a == nil || b == nil || a.Len() != b.Len() ||
+			!floats.Same(a.RawVector().Data, b.RawVector().Data) {
+			report.errorf("seeding does not reproduce trajectories: "+
+				"observations at step %v differ, %v and %v", i,
+				vecData(a), vecData(b))
+			return
+		}
+	}
+	if !floats.Same(first.rewards, second.rewards) {
+		report.errorf("seeding does not reproduce trajectories: rewards " +
+			"differ")
+	}
+}
+
+// generate seeds env and generates a trajectory of at most checkSteps
+// steps, ending at the end of the first episode. If actions is nil,
+// then actions are sampled from the action space and returned.
+// Otherwise, the given actions are taken. Problems with env are added
+// to report, and generate returns whether the trajectory was completed.
+func generate(report *CheckReport, env Environment,
+	actions []*mat.VecDense) (traj trajectory, taken []*mat.VecDense,
+	ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			report.errorf("panic while generating trajectory: %v", r)
+			ok = false
+		}
+	}()
+
+	if _, err := env.Seed(checkSeed); err != nil {
+		report.errorf("seed: %v", err)
+		return traj, nil, false
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		report.errorf("reset: %v", err)
+		return traj, nil, false
+	}
+	valid := checkObservation(report, env, "reset", obs)
+	traj.observations = append(traj.observations, copyVec(obs))
+
+	// Only report the first invalid observation and non-finite reward
+	finite := true
+
+	sample := actions == nil
+	for t := 0; t < checkSteps && (sample || t < len(actions)); t++ {
+		var action *mat.VecDense
+		if sample {
+			var point interface{}
+			point, err = SamplePoint(env.ActionSpace())
+			if err == nil {
+				action, err = PointToVec(env.ActionSpace(), point)
+			}
+			if err != nil {
+				report.errorf("could not sample action: %v", err)
+				return traj, nil, false
+			}
+		} else {
+			action = actions[t]
+		}
+		taken = append(taken, action)
+
+		obs, reward, done, err := env.Step(action)
+		if err != nil {
+			report.errorf("step: sampled action %v not accepted: %v",
+				action.RawVector().Data, err)
+			return traj, nil, false
+		}
+		if valid {
+			valid = checkObservation(report, env, "step", obs)
+		}
+		if finite && (math.IsNaN(reward) || math.IsInf(reward, 0)) {
+			report.warnf("step: non-finite reward %v at step %v", reward, t)
+			finite = false
+		}
+		traj.observations = append(traj.observations, copyVec(obs))
+		traj.rewards = append(traj.rewards, reward)
+
+		if done {
+			break
+		}
+	}
+	return traj, taken, true
+}
+
+// checkObservation adds an error to report if obs, returned by the
+// method with the given name, is not in the observation space of env,
+// and returns whether obs is valid
+func checkObservation(report *CheckReport, env Environment, method string,
+	obs *mat.VecDense) bool {
+	if obs == nil {
+		report.errorf("%v: observation is nil", method)
+		return false
+	}
+	point, err := PointFromVec(env.ObservationSpace(), obs)
+	if err != nil || !env.ObservationSpace().Contains(point) {
+		report.errorf("%v: observation %v not in observation space",
+			method, obs.RawVector().Data)
+		return false
+	}
+	return true
+}
+
+// checkClose closes env twice, adding an error to report if either
+// call panics
+func checkClose(report *CheckReport, env Environment) {
+	for i := 1; i <= 2; i++ {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					report.errorf("close: panic on call %v: %v", i, r)
+				}
+			}()
+			env.Close()
+		}()
+	}
+}
+
+// copyVec returns a copy of v, or nil if v is nil
+func copyVec(v *mat.VecDense) *mat.VecDense {
+	if v == nil {
+		return nil
+	}
+	return mat.VecDenseCopyOf(v)
+}
+
+// vecData returns the data of v, or nil if v is nil
+func vecData(v *mat.VecDense) []float64 {
+	if v == nil {
+		return nil
+	}
+	return v.RawVector().Data
+}
@@ -0,0 +1,170 @@
+package gogym_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// checkedEnvironment is a gogym.Environment implemented in Go with a
+// random walk in [0, 10], used to test CheckEnv. Each of its fields
+// introduces a bug into the environment.
+type checkedEnvironment struct {
+	actionSpace      gogym.Space
+	observationSpace gogym.Space
+	rng              *rand.Rand
+	position         float64
+
+	ignoreSeed    bool    // Seed does not seed the random walk
+	outOfBounds   bool    // Observations are outside the space
+	reward        float64 // Reward given on each step
+	panicOnClose  bool    // Close panics if called more than once
+	rejectActions bool    // Step returns an error
+
+	closed int
+}
+
+// newCheckedEnvironment returns a new checkedEnvironment without bugs
+func newCheckedEnvironment(t *testing.T) *checkedEnvironment {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	obsSpace, err := gogym.NewBox([]float64{0}, []float64{10}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+
+	return &checkedEnvironment{
+		actionSpace:      actionSpace,
+		observationSpace: obsSpace,
+		rng:              rand.New(rand.NewSource(1)),
+	}
+}
+
+func (c *checkedEnvironment) Env() *python.PyObject { return nil }
+
+func (c *checkedEnvironment) Name() string { return "CheckedEnvironment" }
+
+func (c *checkedEnvironment) ContinuousAction() bool { return false }
+
+func (c *checkedEnvironment) Seed(seed int) ([]int, error) {
+	if !c.ignoreSeed {
+		c.rng.Seed(uint64(seed))
+	}
+	return []int{seed}, nil
+}
+
+func (c *checkedEnvironment) ActionSpace() gogym.Space { return c.actionSpace }
+
+func (c *checkedEnvironment) ObservationSpace() gogym.Space {
+	return c.observationSpace
+}
+
+func (c *checkedEnvironment) Step(a *mat.VecDense) (*mat.VecDense, float64,
+	bool, error) {
+	if c.rejectActions {
+		return nil, 0, false, fmt.Errorf("step: illegal action")
+	}
+	c.position = math.Max(0, math.Min(10, c.position+c.rng.NormFloat64()))
+	return c.obs(), c.reward, false, nil
+}
+
+func (c *checkedEnvironment) Reset() (*mat.VecDense, error) {
+	c.position = 10 * c.rng.Float64()
+	return c.obs(), nil
+}
+
+func (c *checkedEnvironment) Info() map[string]interface{} { return nil }
+
+func (c *checkedEnvironment) Close() {
+	c.closed++
+	if c.panicOnClose && c.closed > 1 {
+		panic("close: already closed")
+	}
+}
+
+// obs returns the current observation
+func (c *checkedEnvironment) obs() *mat.VecDense {
+	if c.outOfBounds {
+		return mat.NewVecDense(1, []float64{c.position + 20})
+	}
+	return mat.NewVecDense(1, []float64{c.position})
+}
+
+func TestCheckEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		bug      func(c *checkedEnvironment)
+		errors   int
+		warnings int
+	}{
+		{"none", func(c *checkedEnvironment) {}, 0, 0},
+		{"ignoreSeed", func(c *checkedEnvironment) { c.ignoreSeed = true },
+			1, 0},
+		{"outOfBounds", func(c *checkedEnvironment) { c.outOfBounds = true },
+			1, 0},
+		{"reward", func(c *checkedEnvironment) { c.reward = math.NaN() },
+			0, 1},
+		{"panicOnClose", func(c *checkedEnvironment) {
+			c.panicOnClose = true
+		}, 1, 0},
+		{"rejectActions", func(c *checkedEnvironment) {
+			c.rejectActions = true
+		}, 1, 0},
+		{"nilSpaces", func(c *checkedEnvironment) {
+			c.actionSpace = nil
+			c.observationSpace = nil
+		}, 2, 0},
+	}
+
+	for _, test := range tests {
+		env := newCheckedEnvironment(t)
+		test.bug(env)
+
+		report := gogym.CheckEnv(env)
+		if len(report.Errors) != test.errors ||
+			len(report.Warnings) != test.warnings {
+			t.Errorf("checkEnv: want %v error(s) and %v warning(s) with "+
+				"bug %v have report \n%v", test.errors, test.warnings,
+				test.name, report)
+		}
+		if report.Passed() != (report.Err() == nil) ||
+			report.Passed() != (test.errors == 0) {
+			t.Errorf("checkEnv: want passed(%v) have(%v) with bug %v",
+				test.errors == 0, report.Passed(), test.name)
+		}
+		if env.closed != 2 {
+			t.Errorf("checkEnv: want 2 calls to close have(%v)", env.closed)
+		}
+	}
+
+	// Unbounded observation spaces are warned about
+	env := newCheckedEnvironment(t)
+	unbounded, err := gogym.NewBox([]float64{0}, []float64{math.Inf(1)}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	env.observationSpace = unbounded
+	if report := gogym.CheckEnv(env); !report.Passed() ||
+		len(report.Warnings) != 1 {
+		t.Errorf("checkEnv: want 1 warning for unbounded observation "+
+			"space have report \n%v", report)
+	}
+}
+
+func TestCheckEnvCartPole(t *testing.T) {
+	env, err := gogym.Make("CartPole-v1")
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+
+	if report := gogym.CheckEnv(env); !report.Passed() {
+		t.Errorf("checkEnv: %v", report.Err())
+	}
+}
@@ -0,0 +1,109 @@
+package gogym
+
+import (
+	"fmt"
+	"math"
+)
+
+// DType describes the data type of the elements of a BoxSpace. Each
+// DType is named after its NumPy equivalent, so that a DType can be
+// passed directly to NumPy.
+//
+// Regardless of the DType, points in a BoxSpace are always stored in
+// Go as float64s. The DType determines which of these float64s are
+// legal points in the space and how points are sent to Python.
+type DType string
+
+// Supported data types
+const (
+	Float64 DType = "float64"
+	Float32 DType = "float32"
+	Float16 DType = "float16"
+	Int64   DType = "int64"
+	Int32   DType = "int32"
+	Int16   DType = "int16"
+	Int8    DType = "int8"
+	Uint64  DType = "uint64"
+	Uint32  DType = "uint32"
+	Uint16  DType = "uint16"
+	Uint8   DType = "uint8"
+)
+
+// dtypeRanges stores the range of values representable by each DType
+var dtypeRanges = map[DType][2]float64{
+	Float64: {-math.MaxFloat64, math.MaxFloat64},
+	Float32: {-math.MaxFloat32, math.MaxFloat32},
+	Float16: {-65504, 65504},
+	Int64:   {math.MinInt64, math.MaxInt64},
+	Int32:   {math.MinInt32, math.MaxInt32},
+	Int16:   {math.MinInt16, math.MaxInt16},
+	Int8:    {math.MinInt8, math.MaxInt8},
+	Uint64:  {0, math.MaxUint64},
+	Uint32:  {0, math.MaxUint32},
+	Uint16:  {0, math.MaxUint16},
+	Uint8:   {0, math.MaxUint8},
+}
+
+// ParseDType returns the DType with the given NumPy name
+func ParseDType(name string) (DType, error) {
+	dtype := DType(name)
+	if _, ok := dtypeRanges[dtype]; !ok {
+		return "", fmt.Errorf("parseDType: unsupported data type %v", name)
+	}
+	return dtype, nil
+}
+
+// String returns the NumPy name of the DType
+func (d DType) String() string {
+	return string(d)
+}
+
+// Integer returns whether the DType is an integer data type
+func (d DType) Integer() bool {
+	switch d {
+	case Int64, Int32, Int16, Int8, Uint64, Uint32, Uint16, Uint8:
+		return true
+	}
+	return false
+}
+
+// Range returns the minimum and maximum finite values representable
+// by the DType
+func (d DType) Range() (float64, float64) {
+	r := dtypeRanges[d]
+	return r[0], r[1]
+}
+
+// Convert converts x to the closest value representable by the DType,
+// similar to casting with NumPy's astype. Integer data types truncate
+// x towards zero, and floating point data types round x to the
+// closest value at their precision.
+func (d DType) Convert(x float64) float64 {
+	switch {
+	case d.Integer():
+		return math.Trunc(x)
+
+	case d == Float32 || d == Float16:
+		// Go has no float16, so float32 precision is used as the
+		// closest approximation
+		return float64(float32(x))
+	}
+	return x
+}
+
+// Contains returns whether x is a legal value of the DType. For
+// integer data types, x must be an integer within the range of the
+// DType. For floating point data types, x may be any value other than
+// NaN, since x is rounded to the precision of the DType when sent to
+// Python.
+func (d DType) Contains(x float64) bool {
+	if math.IsNaN(x) {
+		return false
+	}
+	if !d.Integer() {
+		return true
+	}
+
+	min, max := d.Range()
+	return x >= min && x <= max && math.Trunc(x) == x
+}
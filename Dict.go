@@ -2,6 +2,7 @@ package gogym
 
 import (
 	"fmt"
+	"sort"
 
 	python "github.com/DataDog/go-python3"
 	"gonum.org/v1/gonum/mat"
@@ -14,6 +15,14 @@ import (
 // each of its contained spaces' High() methods and returns a
 // []*mat.VecDense resulting from the call to High() on all embedded
 // spaces *in recursive order*.
+//
+// The keys of a DictSpace are kept in a fixed order. Spaces converted
+// from Python keep the key order of the Python gym.spaces.Dict, and
+// spaces constructed with NewDict have sorted keys, as in
+// gym.spaces.Dict. This order is used by Sample, Low, High, Contains,
+// Flatten, Unflatten, and FlattenSpace, so that the layout of
+// flattened points matches that of Python and does not change between
+// runs. The ordered keys can be retrieved with the Keys method.
 type DictSpace struct {
 	// dict  map[string]Space
 	keys   []string
@@ -21,20 +30,23 @@ type DictSpace struct {
 }
 
 // NewDictSpace takes a Python gym.spaces.Dict and converts it into its Go
-// counterpart.
+// counterpart. The keys of the DictSpace keep their order in Python.
 func NewDictSpace(space *python.PyObject) (Space, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("newDictSpace: %v", err)
+	}
 	if !(space.Type() == dictSpace) {
 		return nil, fmt.Errorf("newDictSpace: space is not a dict space")
 	}
 
 	dictSpaces := space.GetAttrString("spaces")
-	defer spaces.DecRef()
-	if spaces == nil || !python.PyDict_Check(dictSpaces) {
+	defer dictSpaces.DecRef()
+	if dictSpaces == nil || !python.PyDict_Check(dictSpaces) {
 		return nil, fmt.Errorf("newDictSpace: space is not a DictSpace")
 	}
 
 	// Get the keys in the Python Dict space
-	keys := python.PyDict_Items(dictSpaces)
+	keys := python.PyDict_Keys(dictSpaces)
 	defer keys.DecRef()
 	if keys == nil {
 		return nil, fmt.Errorf("newDictSpace: no keys in DictSpace")
@@ -49,8 +61,7 @@ func NewDictSpace(space *python.PyObject) (Space, error) {
 
 	// Convert to DictSpace
 	values := make([]Space, len(goKeys))
-	i := 0
-	for _, key := range goKeys {
+	for i, key := range goKeys {
 		spaceAtKey := python.PyDict_GetItemString(dictSpaces, key)
 		value, err := FromPythonSpace(spaceAtKey)
 
@@ -59,16 +70,70 @@ func NewDictSpace(space *python.PyObject) (Space, error) {
 				err)
 		}
 		values[i] = value
-		i++
 	}
 
-	return &DictSpace{goKeys, values}, nil
+	dict, err := newDict(goKeys, values)
+	if err != nil {
+		return nil, fmt.Errorf("newDictSpace: %v", err)
+	}
+
+	return dict, nil
 }
 
-// Seed seeds the RNG for all sub-spaces recursively
+// NewDict returns a new DictSpace which maps each key in keys to the
+// space at the same index in spaces, without requiring a Python
+// gym.spaces.Dict. The keys are sorted, regardless of the order in
+// which they are given.
+func NewDict(keys []string, spaces []Space) (*DictSpace, error) {
+	if len(keys) != len(spaces) {
+		return nil, fmt.Errorf("newDict: keys and spaces must have the same "+
+			"length \n\twant(%v) \n\thave(%v)", len(keys), len(spaces))
+	}
+
+	// Sort the keys, keeping each space with its key
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return keys[order[i]] < keys[order[j]]
+	})
+
+	goKeys := make([]string, len(keys))
+	values := make([]Space, len(spaces))
+	for i, j := range order {
+		goKeys[i] = keys[j]
+		values[i] = spaces[j]
+	}
+
+	return newDict(goKeys, values)
+}
+
+// newDict returns a new DictSpace which maps each key in keys to the
+// space at the same index in spaces, keeping the keys in the order
+// given. The keys and spaces must have the same length.
+func newDict(keys []string, spaces []Space) (*DictSpace, error) {
+	seen := make(map[string]struct{}, len(keys))
+	for i, key := range keys {
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("newDict: duplicate key %v", key)
+		}
+		seen[key] = struct{}{}
+
+		if spaces[i] == nil {
+			return nil, fmt.Errorf("newDict: nil space at key %v", key)
+		}
+	}
+
+	return &DictSpace{keys, spaces}, nil
+}
+
+// Seed seeds the RNG for all sub-spaces recursively. Each sub-space is
+// seeded with a different seed derived from the argument seed, so
+// that sub-spaces do not produce correlated samples.
 func (d *DictSpace) Seed(seed uint64) {
-	for _, space := range d.values {
-		space.Seed(seed)
+	for i, subseed := range subseeds(seed, len(d.values)) {
+		d.values[i].Seed(subseed)
 	}
 }
 
@@ -85,6 +150,23 @@ func (d *DictSpace) Sample() []*mat.VecDense {
 	return sample
 }
 
+// SamplePoint takes a sample from within the space bounds and returns
+// it as a map[string]interface{}, which maps each key to a point
+// sampled from the sub-space at that key. SamplePoint panics if a
+// point cannot be sampled from a sub-space (see the SamplePoint
+// function).
+func (d *DictSpace) SamplePoint() interface{} {
+	sample := make(map[string]interface{}, d.Len())
+	for i, key := range d.keys {
+		point, err := SamplePoint(d.values[i])
+		if err != nil {
+			panic(fmt.Sprintf("samplePoint: key %v: %v", key, err))
+		}
+		sample[key] = point
+	}
+	return sample
+}
+
 // Contains returns whether in is in the space. The argument in must
 // be a map[string]interface{}
 func (d *DictSpace) Contains(in interface{}) bool {
@@ -133,6 +215,14 @@ func (d *DictSpace) High() []*mat.VecDense {
 	return high
 }
 
+// Keys returns the keys of the space in the order used for all
+// sampling, bounds, and flattening
+func (d *DictSpace) Keys() []string {
+	keys := make([]string, len(d.keys))
+	copy(keys, d.keys)
+	return keys
+}
+
 // Len returns the number of sub-spaces in the space
 func (d *DictSpace) Len() int {
 	return len(d.keys)
@@ -147,3 +237,51 @@ func (d *DictSpace) At(key string) (Space, error) {
 	}
 	return nil, fmt.Errorf("at: key %v not in DictSpace", key)
 }
+
+// toPython converts the DictSpace to a Python gym.spaces.Dict. Creates
+// a new python.PyObject reference.
+func (d *DictSpace) toPython() (*python.PyObject, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("toPython: %v", err)
+	}
+
+	// Pass the sub-spaces as a list of (key, space) pairs, since
+	// gym.spaces.Dict keeps the order of a list but may sort the keys
+	// of a dict
+	pyItems := python.PyList_New(len(d.keys))
+	defer pyItems.DecRef()
+
+	for i, key := range d.keys {
+		value, err := ToPython(d.values[i])
+		if err != nil {
+			return nil, fmt.Errorf("toPython: could not convert space at "+
+				"key %v: %v", key, err)
+		}
+
+		// PyTuple_SetItem and PyList_SetItem steal the references to
+		// their items
+		item := python.PyTuple_New(2)
+		python.PyTuple_SetItem(item, 0, python.PyUnicode_FromString(key))
+		python.PyTuple_SetItem(item, 1, value)
+		if python.PyList_SetItem(pyItems, i, item) != 0 {
+			if python.PyErr_Occurred() != nil {
+				python.PyErr_Print()
+			}
+			return nil, fmt.Errorf("toPython: could not set key %v", key)
+		}
+	}
+
+	args := python.PyTuple_New(1)
+	defer args.DecRef()
+	pyItems.IncRef()
+	python.PyTuple_SetItem(args, 0, pyItems)
+
+	space := dictSpace.CallObject(args)
+	if space == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("toPython: could not construct gym.spaces.Dict")
+	}
+	return space, nil
+}
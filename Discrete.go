@@ -2,7 +2,6 @@ package gogym
 
 import (
 	"fmt"
-	"time"
 
 	python "github.com/DataDog/go-python3"
 	"golang.org/x/exp/rand"
@@ -10,19 +9,26 @@ import (
 	"gonum.org/v1/gonum/stat/distuv"
 )
 
-// DiscreteSpace represents a space of discrete numbers: (0, 1, 2, ..., n-1).
+// DiscreteSpace represents a space of discrete numbers:
+// (start, start+1, ..., start+n-1). By default, start is 0.
 //
-// The DiscreteSpace space must be constructed from its Python equivalent.
+// A DiscreteSpace can be constructed either from its Python equivalent
+// using NewDiscreteSpace or directly in Go using NewDiscrete. If
+// constructed in Go, the embedded Python space is nil.
 type DiscreteSpace struct {
-	*python.PyObject
+	*python.PyObject // DiscreteSpace Space, nil if constructed in Go
 	rand.Source
-	rng distuv.Categorical
-	n   int // Number of actions, actions in (0, 1, ..., n-1)
+	rng   distuv.Categorical
+	n     int // Number of actions, actions in (start, ..., start+n-1)
+	start int // Smallest element of the space
 }
 
 // NewDiscreteSpace takes a Python gym.spaces.DiscreteSpace and converts it into
 // its Go counterpart.
 func NewDiscreteSpace(space *python.PyObject) (Space, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("newDiscreteSpace: %v", err)
+	}
 	if !(space.Type() == discreteSpace) {
 		return nil, fmt.Errorf("newDiscreteSpace: space is not a discrete " +
 			"space")
@@ -35,7 +41,39 @@ func NewDiscreteSpace(space *python.PyObject) (Space, error) {
 	}
 	n := python.PyLong_AsLong(pythonN)
 
-	src := rand.NewSource(uint64(time.Now().UnixNano()))
+	// Older versions of gym do not support a start for Discrete spaces
+	start := 0
+	if space.HasAttrString("start") {
+		pythonStart := space.GetAttrString("start")
+		defer pythonStart.DecRef()
+		start = python.PyLong_AsLong(pythonStart)
+	}
+
+	discrete, err := NewDiscreteStart(n, start)
+	if err != nil {
+		return nil, fmt.Errorf("newDiscreteSpace: %v", err)
+	}
+	discrete.PyObject = space
+
+	return discrete, nil
+}
+
+// NewDiscrete returns a new DiscreteSpace of n elements starting at 0,
+// without requiring a Python gym.spaces.Discrete.
+func NewDiscrete(n int) (*DiscreteSpace, error) {
+	return NewDiscreteStart(n, 0)
+}
+
+// NewDiscreteStart returns a new DiscreteSpace of n elements starting
+// at start, that is (start, start+1, ..., start+n-1), without
+// requiring a Python gym.spaces.Discrete.
+func NewDiscreteStart(n, start int) (*DiscreteSpace, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("newDiscreteStart: n must be positive, got %v",
+			n)
+	}
+
+	src := rand.NewSource(newSeed())
 	weights := make([]float64, n)
 	for i := range weights {
 		weights[i] = 1.0
@@ -43,10 +81,10 @@ func NewDiscreteSpace(space *python.PyObject) (Space, error) {
 	rng := distuv.NewCategorical(weights, src)
 
 	return &DiscreteSpace{
-		PyObject: space,
-		Source:   src,
-		rng:      rng,
-		n:        n,
+		Source: src,
+		rng:    rng,
+		n:      n,
+		start:  start,
 	}, nil
 }
 
@@ -54,32 +92,126 @@ func NewDiscreteSpace(space *python.PyObject) (Space, error) {
 func (d *DiscreteSpace) Sample() []*mat.VecDense {
 	return []*mat.VecDense{
 		mat.NewVecDense(1, []float64{
-			float64(int(d.rng.Rand()) % d.n),
+			float64(int(d.rng.Rand())%d.n + d.start),
 		}),
 	}
 }
 
+// SamplePoint takes a sample from within the space bounds and returns
+// it as an int
+func (d *DiscreteSpace) SamplePoint() interface{} {
+	return int(d.rng.Rand())%d.n + d.start
+}
+
+// SampleMasked takes a sample uniformly from the elements of the
+// space which are legal according to mask. Element i of mask is true
+// if element i of the space, that is start+i, is legal.
+func (d *DiscreteSpace) SampleMasked(mask []bool) (int, error) {
+	if len(mask) != d.n {
+		return 0, fmt.Errorf("sampleMasked: mask should have same length "+
+			"as space \n\twant(%v) \n\thave(%v)", d.n, len(mask))
+	}
+
+	weights := make([]float64, d.n)
+	for i := range mask {
+		if mask[i] {
+			weights[i] = 1.0
+		}
+	}
+
+	i, err := d.SampleWeighted(weights)
+	if err != nil {
+		return 0, fmt.Errorf("sampleMasked: %v", err)
+	}
+	return i, nil
+}
+
+// SampleWeighted takes a sample from the elements of the space, where
+// element i, that is start+i, is sampled with probability proportional
+// to weights[i]. Elements with zero weight are never sampled.
+func (d *DiscreteSpace) SampleWeighted(weights []float64) (int, error) {
+	if len(weights) != d.n {
+		return 0, fmt.Errorf("sampleWeighted: weights should have same "+
+			"length as space \n\twant(%v) \n\thave(%v)", d.n, len(weights))
+	}
+
+	var total float64
+	for _, w := range weights {
+		if w < 0 {
+			return 0, fmt.Errorf("sampleWeighted: weights must be " +
+				"non-negative")
+		}
+		total += w
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("sampleWeighted: at least one weight must be " +
+			"positive")
+	}
+
+	rng := distuv.NewCategorical(weights, d.Source)
+	return int(rng.Rand()) + d.start, nil
+}
+
 // Contains returns whether in is in the space. The argument in
-// should be a []float64 or *mat.VecDense.
+// should be an integer or floating point type with an integral value,
+// or a []float64 or *mat.VecDense with a single element.
 func (d *DiscreteSpace) Contains(in interface{}) bool {
-	x, ok := in.([]float64)
-	if !ok {
-		vec, ok := in.(*mat.VecDense)
-		if !ok {
-			return false
-		}
-		x = vec.RawVector().Data
+	x, err := discreteIndex(in)
+	if err != nil {
+		return false
 	}
-	intX := int(x[0])
-	return len(x) == 1 && intX < d.n && intX >= 0
+	return x < d.start+d.n && x >= d.start
 }
 
 // High returns the upper bounds of the space
 func (d *DiscreteSpace) High() []*mat.VecDense {
-	return []*mat.VecDense{mat.NewVecDense(1, []float64{float64(d.n - 1)})}
+	return []*mat.VecDense{
+		mat.NewVecDense(1, []float64{float64(d.start + d.n - 1)}),
+	}
 }
 
 // Low returns the lower bounds of the space
 func (d *DiscreteSpace) Low() []*mat.VecDense {
-	return []*mat.VecDense{mat.NewVecDense(1, []float64{1.0})}
+	return []*mat.VecDense{mat.NewVecDense(1, []float64{float64(d.start)})}
+}
+
+// toPython converts the DiscreteSpace to a Python gym.spaces.Discrete.
+// Creates a new python.PyObject reference.
+func (d *DiscreteSpace) toPython() (*python.PyObject, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("toPython: %v", err)
+	}
+	args := python.PyTuple_New(1)
+	defer args.DecRef()
+	python.PyTuple_SetItem(args, 0, python.PyLong_FromGoInt(d.n))
+
+	// Only pass the start if needed, since older versions of gym do not
+	// support it
+	kwargs := python.PyDict_New()
+	defer kwargs.DecRef()
+	if d.start != 0 {
+		start := python.PyLong_FromGoInt(d.start)
+		defer start.DecRef()
+		python.PyDict_SetItemString(kwargs, "start", start)
+	}
+
+	space := discreteSpace.Call(args, kwargs)
+	if space == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("toPython: could not construct " +
+			"gym.spaces.Discrete")
+	}
+	return space, nil
+}
+
+// N returns the number of elements in the space
+func (d *DiscreteSpace) N() int {
+	return d.n
+}
+
+// Start returns the smallest element of the space
+func (d *DiscreteSpace) Start() int {
+	return d.start
 }
@@ -0,0 +1,311 @@
+package gogym
+
+import (
+	"fmt"
+
+	python "github.com/DataDog/go-python3"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Env is a type-parameterized environment with observations of type O
+// and actions of type A. Unlike Environment, which passes all
+// observations and actions as *mat.VecDense, an Env passes points of
+// its observation and action spaces in their structured form (see the
+// Space interface for the representation of each point), so that the
+// types of observations and actions are checked at compile time.
+//
+// An Environment can be converted to an Env using NewEnv, and an Env
+// can be converted back to an Environment using ToEnvironment.
+type Env[O, A any] interface {
+	// Name gets the name of the environment
+	Name() string
+
+	// Seed seeds the Env and returns the seed. It is equivalent
+	// to calling env.seed(seed) in Python's OpenAI Gym.
+	Seed(seed int) ([]int, error)
+
+	// ActionSpace returns the action space as a Go data structure
+	ActionSpace() Space
+
+	// ObservationSpace returns the observation space as a Go data
+	// structure
+	ObservationSpace() Space
+
+	// Step takes one environmental step given some action a and returns
+	// the next observation, reward, and a flag indicating if the
+	// episode has completed.
+	Step(a A) (O, float64, bool, error)
+
+	// Reset resets the Env and returns the starting state
+	Reset() (O, error)
+
+	// Info returns the info dictionary returned by the most recent
+	// call to Step or Reset. The returned map should not be modified.
+	Info() map[string]interface{}
+
+	// Close performs cleanup of environment resources. It should be
+	// called once the environment is no longer needed.
+	Close()
+}
+
+// DiscreteActionEnv is an Env with a BoxSpace observation space and a
+// DiscreteSpace action space
+type DiscreteActionEnv = Env[*mat.VecDense, int]
+
+// ContinuousActionEnv is an Env with a BoxSpace observation space and
+// a BoxSpace action space
+type ContinuousActionEnv = Env[*mat.VecDense, *mat.VecDense]
+
+// NewDiscreteActionEnv converts env, which must have a BoxSpace
+// observation space and a DiscreteSpace action space, to a
+// DiscreteActionEnv
+func NewDiscreteActionEnv(env Environment) (DiscreteActionEnv, error) {
+	if _, ok := env.ObservationSpace().(*BoxSpace); !ok {
+		return nil, fmt.Errorf("newDiscreteActionEnv: observation space "+
+			"should be a BoxSpace but got %T", env.ObservationSpace())
+	}
+	if _, ok := env.ActionSpace().(*DiscreteSpace); !ok {
+		return nil, fmt.Errorf("newDiscreteActionEnv: action space should "+
+			"be a DiscreteSpace but got %T", env.ActionSpace())
+	}
+
+	typed, err := NewEnv[*mat.VecDense, int](env)
+	if err != nil {
+		return nil, fmt.Errorf("newDiscreteActionEnv: %v", err)
+	}
+	return typed, nil
+}
+
+// NewContinuousActionEnv converts env, which must have a BoxSpace
+// observation space and a BoxSpace action space, to a
+// ContinuousActionEnv
+func NewContinuousActionEnv(env Environment) (ContinuousActionEnv, error) {
+	if _, ok := env.ObservationSpace().(*BoxSpace); !ok {
+		return nil, fmt.Errorf("newContinuousActionEnv: observation space "+
+			"should be a BoxSpace but got %T", env.ObservationSpace())
+	}
+	if _, ok := env.ActionSpace().(*BoxSpace); !ok {
+		return nil, fmt.Errorf("newContinuousActionEnv: action space "+
+			"should be a BoxSpace but got %T", env.ActionSpace())
+	}
+
+	typed, err := NewEnv[*mat.VecDense, *mat.VecDense](env)
+	if err != nil {
+		return nil, fmt.Errorf("newContinuousActionEnv: %v", err)
+	}
+	return typed, nil
+}
+
+// typedEnv adapts an Environment to an Env
+type typedEnv[O, A any] struct {
+	Environment
+}
+
+// NewEnv converts env to an Env with observations of type O and
+// actions of type A. Types O and A must be the types of the points in
+// the observation and action spaces of env respectively, as described
+// by the Space interface.
+func NewEnv[O, A any](env Environment) (Env[O, A], error) {
+	if env.ObservationSpace() == nil || env.ActionSpace() == nil {
+		return nil, fmt.Errorf("newEnv: cannot convert environment with " +
+			"unsupported observation or action space")
+	}
+
+	if _, ok := zeroPoint(env.ObservationSpace()).(O); !ok {
+		var o O
+		return nil, fmt.Errorf("newEnv: observation type %T does not match "+
+			"observation space %T", o, env.ObservationSpace())
+	}
+	if _, ok := zeroPoint(env.ActionSpace()).(A); !ok {
+		var a A
+		return nil, fmt.Errorf("newEnv: action type %T does not match "+
+			"action space %T", a, env.ActionSpace())
+	}
+
+	return &typedEnv[O, A]{env}, nil
+}
+
+// zeroPoint returns the zero value of the type of the points in space,
+// as described by the Space interface, or nil if space is not
+// supported. Unlike sampling, it does not advance the RNG of space.
+func zeroPoint(space Space) interface{} {
+	switch space.(type) {
+	case *BoxSpace:
+		return (*mat.VecDense)(nil)
+
+	case *DiscreteSpace:
+		return 0
+
+	case *TupleSpace:
+		return []interface{}(nil)
+
+	case *DictSpace:
+		return map[string]interface{}(nil)
+	}
+	return nil
+}
+
+// Step takes one environmental step given some action a
+func (t *typedEnv[O, A]) Step(a A) (O, float64, bool, error) {
+	var zero O
+
+	action, err := PointToVec(t.ActionSpace(), a)
+	if err != nil {
+		return zero, 0, false, fmt.Errorf("step: %v", err)
+	}
+
+	obs, reward, done, err := t.Environment.Step(action)
+	if err != nil {
+		return zero, 0, false, fmt.Errorf("step: %v", err)
+	}
+
+	point, err := t.observation(obs)
+	if err != nil {
+		return zero, 0, false, fmt.Errorf("step: %v", err)
+	}
+	return point, reward, done, nil
+}
+
+// Reset resets the environment and returns the starting state
+func (t *typedEnv[O, A]) Reset() (O, error) {
+	var zero O
+
+	obs, err := t.Environment.Reset()
+	if err != nil {
+		return zero, fmt.Errorf("reset: %v", err)
+	}
+
+	point, err := t.observation(obs)
+	if err != nil {
+		return zero, fmt.Errorf("reset: %v", err)
+	}
+	return point, nil
+}
+
+// Info returns the info dictionary of the wrapped Environment, or an
+// empty map if it does not implement InfoEnvironment
+func (t *typedEnv[O, A]) Info() map[string]interface{} {
+	return Info(t.Environment)
+}
+
+// observation converts an observation vector of the wrapped
+// Environment to an observation of type O
+func (t *typedEnv[O, A]) observation(obs *mat.VecDense) (O, error) {
+	var zero O
+
+	point, err := PointFromVec(t.ObservationSpace(), obs)
+	if err != nil {
+		return zero, err
+	}
+
+	o, ok := point.(O)
+	if !ok {
+		return zero, fmt.Errorf("observation %v is not of type %T", point,
+			zero)
+	}
+	return o, nil
+}
+
+// environment adapts an Env to an Environment
+type environment[O, A any] struct {
+	env Env[O, A]
+}
+
+// ToEnvironment converts env to an Environment, which passes all
+// observations and actions as vectors, as described by PointToVec.
+// If env was created by NewEnv, then the original Environment is
+// returned.
+func ToEnvironment[O, A any](env Env[O, A]) Environment {
+	if t, ok := env.(*typedEnv[O, A]); ok {
+		return t.Environment
+	}
+	return &environment[O, A]{env}
+}
+
+// Name gets the name of the environment
+func (e *environment[O, A]) Name() string {
+	return e.env.Name()
+}
+
+// Seed seeds the environment and returns the seed
+func (e *environment[O, A]) Seed(seed int) ([]int, error) {
+	return e.env.Seed(seed)
+}
+
+// ActionSpace returns the action space as a Go data structure
+func (e *environment[O, A]) ActionSpace() Space {
+	return e.env.ActionSpace()
+}
+
+// ObservationSpace returns the observation space as a Go data structure
+func (e *environment[O, A]) ObservationSpace() Space {
+	return e.env.ObservationSpace()
+}
+
+// Info returns the info dictionary returned by the most recent call
+// to Step or Reset
+func (e *environment[O, A]) Info() map[string]interface{} {
+	return e.env.Info()
+}
+
+// Close performs cleanup of environment resources
+func (e *environment[O, A]) Close() {
+	e.env.Close()
+}
+
+// Env gets the Python OpenAI Gym environment, or nil if the
+// environment is not backed by Python
+func (e *environment[O, A]) Env() *python.PyObject {
+	if pyEnv, ok := e.env.(interface{ Env() *python.PyObject }); ok {
+		return pyEnv.Env()
+	}
+	return nil
+}
+
+// ContinuousAction returns whether or not the environment has
+// continuous actions
+func (e *environment[O, A]) ContinuousAction() bool {
+	_, ok := e.ActionSpace().(*BoxSpace)
+	return ok
+}
+
+// Step takes one environmental step given some action vector a
+func (e *environment[O, A]) Step(a *mat.VecDense) (*mat.VecDense, float64,
+	bool, error) {
+	point, err := PointFromVec(e.ActionSpace(), a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+
+	action, ok := point.(A)
+	if !ok {
+		return nil, 0, false, fmt.Errorf("step: action %v is not of type %T",
+			point, action)
+	}
+
+	obs, reward, done, err := e.env.Step(action)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+
+	vec, err := PointToVec(e.ObservationSpace(), obs)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+	return vec, reward, done, nil
+}
+
+// Reset resets the environment and returns the starting state as a
+// vector
+func (e *environment[O, A]) Reset() (*mat.VecDense, error) {
+	obs, err := e.env.Reset()
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+
+	vec, err := PointToVec(e.ObservationSpace(), obs)
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+	return vec, nil
+}
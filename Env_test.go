@@ -0,0 +1,142 @@
+package gogym_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewDiscreteActionEnv(t *testing.T) {
+	cartpole, err := gogym.Make("CartPole-v1")
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+	defer cartpole.Close()
+
+	env, err := gogym.NewDiscreteActionEnv(cartpole)
+	if err != nil {
+		t.Fatalf("newDiscreteActionEnv: %v", err)
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if !env.ObservationSpace().Contains(obs) {
+		t.Errorf("reset: observation %v not in observation space", obs)
+	}
+
+	for done := false; !done; {
+		obs, _, done, err = env.Step(1)
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		if !env.ObservationSpace().Contains(obs) {
+			t.Errorf("step: observation %v not in observation space", obs)
+		}
+	}
+
+	// Converting back should return the original environment
+	if gogym.ToEnvironment(env) != cartpole {
+		t.Errorf("toEnvironment: did not return original environment")
+	}
+
+	// CartPole does not have continuous actions
+	if _, err = gogym.NewContinuousActionEnv(cartpole); err == nil {
+		t.Errorf("newContinuousActionEnv: expected error for " +
+			"DiscreteSpace actions")
+	}
+	if _, err = gogym.NewEnv[*mat.VecDense, []interface{}](cartpole); err ==
+		nil {
+		t.Errorf("newEnv: expected error for mismatched action type")
+	}
+}
+
+func TestToEnvironment(t *testing.T) {
+	mountainCar, err := gogym.Make("MountainCarContinuous-v0")
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+	defer mountainCar.Close()
+
+	env, err := gogym.NewContinuousActionEnv(mountainCar)
+	if err != nil {
+		t.Fatalf("newContinuousActionEnv: %v", err)
+	}
+
+	// Hide the concrete type of env, so that ToEnvironment must adapt it
+	wrapped := gogym.ToEnvironment[*mat.VecDense, *mat.VecDense](
+		struct{ gogym.ContinuousActionEnv }{env})
+	if !wrapped.ContinuousAction() {
+		t.Errorf("continuousAction: want(true) have(false)")
+	}
+
+	if _, err = wrapped.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	obs, _, _, err := wrapped.Step(mat.NewVecDense(1, []float64{0.5}))
+	if err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if !wrapped.ObservationSpace().Contains(obs) {
+		t.Errorf("step: observation %v not in observation space", obs)
+	}
+}
+
+func TestNewEnvSpaces(t *testing.T) {
+	env := newCheckedEnvironment(t)
+	env.ActionSpace().Seed(1)
+	env.ObservationSpace().Seed(1)
+
+	if _, err := gogym.NewEnv[*mat.VecDense, int](env); err != nil {
+		t.Fatalf("newEnv: %v", err)
+	}
+	if _, err := gogym.NewEnv[*mat.VecDense, float64](env); err == nil {
+		t.Errorf("newEnv: expected error for mismatched action type")
+	}
+	if _, err := gogym.NewEnv[int, int](env); err == nil {
+		t.Errorf("newEnv: expected error for mismatched observation type")
+	}
+
+	// Checking the types should not sample from the spaces
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	actionSpace.Seed(1)
+	for i := 0; i < 10; i++ {
+		want := actionSpace.SamplePoint()
+		have, err := gogym.SamplePoint(env.ActionSpace())
+		if err != nil {
+			t.Fatalf("samplePoint: %v", err)
+		}
+		if want != have {
+			t.Fatalf("newEnv: action space RNG advanced, sample %v: "+
+				"want(%v) have(%v)", i, want, have)
+		}
+	}
+
+	// Composite observation spaces
+	tuple, err := gogym.NewTuple(env.ObservationSpace(), actionSpace)
+	if err != nil {
+		t.Fatalf("newTuple: %v", err)
+	}
+	env.observationSpace = tuple
+	if _, err = gogym.NewEnv[[]interface{}, int](env); err != nil {
+		t.Errorf("newEnv: %v", err)
+	}
+
+	dict, err := gogym.NewDict([]string{"position"},
+		[]gogym.Space{env.ActionSpace()})
+	if err != nil {
+		t.Fatalf("newDict: %v", err)
+	}
+	env.observationSpace = dict
+	if _, err = gogym.NewEnv[map[string]interface{}, int](env); err != nil {
+		t.Errorf("newEnv: %v", err)
+	}
+	if _, err = gogym.NewEnv[[]interface{}, int](env); err == nil {
+		t.Errorf("newEnv: expected error for mismatched observation type")
+	}
+}
@@ -12,9 +12,11 @@ package gogym
 // #include "/home/samuel/.local/lib/python3.7/site-packages/numpy/core/include/numpy/arrayobject.h"
 import "C"
 import (
+	"context"
 	"fmt"
 	"os"
-	"reflect"
+	"strconv"
+	"strings"
 
 	python "github.com/DataDog/go-python3"
 	"gonum.org/v1/gonum/mat"
@@ -23,63 +25,140 @@ import (
 // Set of open environments
 var openEnvironments map[Environment]struct{} = make(map[Environment]struct{})
 
-// Python modules
+// Python modules, imported lazily by importGym
 var gym *python.PyObject
 var dict *python.PyObject
+var numpy *python.PyObject
 
-// Space types
+// Space types, imported lazily by importGym
 var spaces *python.PyObject
 var boxSpace *python.PyObject
 var discreteSpace *python.PyObject
 var dictSpace *python.PyObject
 var tupleSpace *python.PyObject
 
+// gymNewAPI is whether the imported gym is version 0.26 or later, in
+// which env.reset() returns an (observation, info) tuple and env.step
+// returns (observation, reward, terminated, truncated, info). It is
+// set by importGym.
+var gymNewAPI bool
+
 // Closed indicates whether the package has been closed or not
 var Closed bool = false
 
-// init performs setup for the package before running
+// init performs setup for the package before running. The gym and
+// numpy Python modules are not imported until they are needed (see
+// importGym), so that spaces and environments implemented in Go can be
+// used without either module installed.
 func init() {
 	// Initialize the Python interpreter
 	python.Py_Initialize()
+}
+
+// importGym imports the gym and numpy Python modules and the Python
+// space types if they have not been imported yet. It must be called by
+// any function which uses them, and returns an error if they cannot be
+// imported.
+func importGym() error {
+	if gym != nil {
+		return nil
+	}
 
 	// Import gym
 	gymModule := python.PyImport_ImportModule("gym")
 	if gymModule == nil {
-		if python.PyErr_Occurred() != nil {
-			python.PyErr_Print()
-		}
-		panic("init: could not import gym")
+		return fmt.Errorf("importGym: could not import gym: %v",
+			pythonError())
 	}
 	defer gymModule.DecRef()
-	gym = python.PyImport_AddModule("gym")
-	gymModule.IncRef()
+
+	// Check the version of gym, which determines the values returned by
+	// env.reset() and env.step
+	version := gymModule.GetAttrString("__version__")
+	defer version.DecRef()
+	if version == nil {
+		return fmt.Errorf("importGym: could not get gym version: %v",
+			pythonError())
+	}
+	newAPI, err := versionAtLeast(python.PyUnicode_AsUTF8(version), 0, 26)
+	if err != nil {
+		return fmt.Errorf("importGym: %v", err)
+	}
+
+	// Import numpy, which is needed to construct Python spaces from Go
+	numpyModule := python.PyImport_ImportModule("numpy")
+	if numpyModule == nil {
+		return fmt.Errorf("importGym: could not import numpy: %v",
+			pythonError())
+	}
+
+	spacesModule := gymModule.GetAttrString("spaces")
+	if spacesModule == nil {
+		numpyModule.DecRef()
+		return fmt.Errorf("importGym: could not import gym.spaces")
+	}
+
+	// Get the Python space types
+	names := []string{"Box", "Discrete", "Dict", "Tuple"}
+	types := make([]*python.PyObject, len(names))
+	for i, name := range names {
+		types[i] = spacesModule.GetAttrString(name)
+		if types[i] == nil {
+			for _, spaceType := range types[:i] {
+				spaceType.DecRef()
+			}
+			spacesModule.DecRef()
+			numpyModule.DecRef()
+			return fmt.Errorf("importGym: could not get Python %v space "+
+				"type", name)
+		}
+	}
 
 	// ! These needs to be closed after
+	gym = python.PyImport_AddModule("gym")
+	gymModule.IncRef()
 	dict = python.PyModule_GetDict(gym)
 	dict.IncRef()
+	numpy = numpyModule
+	spaces = spacesModule
+	boxSpace, discreteSpace = types[0], types[1]
+	dictSpace, tupleSpace = types[2], types[3]
+	gymNewAPI = newAPI
+	return nil
+}
 
-	spaces = python.PyDict_GetItemString(dict, "spaces")
-	spaces.IncRef()
-
-	boxSpace = spaces.GetAttrString("Box")
-	if boxSpace == nil {
-		panic("init: could not get Python BoxSpace space type")
+// versionAtLeast returns whether the version string version, such as
+// "0.26.2", is at least major.minor
+func versionAtLeast(version string, major, minor int) (bool, error) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false, fmt.Errorf("versionAtLeast: could not parse version %v",
+			version)
 	}
 
-	discreteSpace = spaces.GetAttrString("Discrete")
-	if discreteSpace == nil {
-		panic("init: could not get Python DiscreteSpace space type")
+	// Ignore suffixes of the minor version, such as "rc1" in "26rc1"
+	minorPart := parts[1]
+	if i := strings.IndexFunc(minorPart, func(r rune) bool {
+		return r < '0' || r > '9'
+	}); i >= 0 {
+		minorPart = minorPart[:i]
 	}
 
-	dictSpace = spaces.GetAttrString("Dict")
-	if dictSpace == nil {
-		panic("init: could not get Python Dict space type")
+	vMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false, fmt.Errorf("versionAtLeast: could not parse version "+
+			"%v: %v", version, err)
+	}
+	vMinor, err := strconv.Atoi(minorPart)
+	if err != nil {
+		return false, fmt.Errorf("versionAtLeast: could not parse version "+
+			"%v: %v", version, err)
 	}
 
-	tupleSpace = spaces.GetAttrString("Tuple")
-	if tupleSpace == nil {
-		panic("init: could not get Python Tuple space type")
+	if vMajor != major {
+		return vMajor > major, nil
 	}
+	return vMinor >= minor, nil
 }
 
 // Environment describes an OpenAI Gym environment
@@ -130,6 +209,16 @@ type GymEnv struct {
 
 	actionSpace      Space
 	observationSpace Space
+
+	info map[string]interface{}
+
+	// needsReset is true if a call to step or reset was interrupted
+	// after its context was done, in which case the environment must be
+	// reset before stepping again
+	needsReset bool
+
+	// closed is true if Close has been called
+	closed bool
 }
 
 // New creates and returns a new *GymEnv. The argument PyObject env
@@ -145,6 +234,7 @@ func New(env *python.PyObject, envName string, continuousAction bool,
 		continuousAction: continuousAction,
 		actionSpace:      actionSpace,
 		observationSpace: observationSpace,
+		info:             make(map[string]interface{}),
 	}
 	env.IncRef()
 
@@ -158,19 +248,17 @@ func Make(envName string) (Environment, error) {
 	if Closed {
 		panic("make: cannot create environment when package closed")
 	}
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("make: %v", err)
+	}
+
 	// Get the gym.make function
 	makeEnv := python.PyDict_GetItemString(dict, "make")
 	makeEnv.IncRef()
 	defer makeEnv.DecRef()
 	if !(makeEnv != nil && python.PyCallable_Check(makeEnv)) {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		return nil, fmt.Errorf("make: error creating env %v", envName)
+		return nil, fmt.Errorf("make: error creating env %v: %v", envName,
+			pythonError())
 	}
 
 	// Construct the arguments to the gym.make function
@@ -181,15 +269,17 @@ func Make(envName string) (Environment, error) {
 	// Create the gym environment
 	gymEnv := makeEnv.CallObject(args)
 	if gymEnv == nil {
-		if python.PyErr_Occurred() != nil {
-			python.PyErr_Print()
-		}
-		return nil, fmt.Errorf("make: could not call gym.make")
+		return nil, fmt.Errorf("make: could not make env %v: %v", envName,
+			pythonError())
 	}
 
 	// Figure out if the environment has continuous actions or not
 	actionSpace := gymEnv.GetAttrString("action_space")
 	defer actionSpace.DecRef()
+	if actionSpace == nil {
+		return nil, fmt.Errorf("make: could not get action space of env "+
+			"%v: %v", envName, pythonError())
+	}
 	continuousAction := actionSpace.Type() == boxSpace
 
 	// Construct the action space
@@ -217,10 +307,11 @@ func Make(envName string) (Environment, error) {
 
 	// Construct the observation space
 	observationSpace := gymEnv.GetAttrString("observation_space")
+	defer observationSpace.DecRef()
 	if observationSpace == nil {
-		fmt.Println("\n\n Obs nil")
+		return nil, fmt.Errorf("make: could not get observation space of "+
+			"env %v: %v", envName, pythonError())
 	}
-	defer observationSpace.DecRef()
 	var goObservationSpace Space
 	if observationSpace.Type() == boxSpace {
 		goObservationSpace, err = NewBoxSpace(observationSpace)
@@ -236,6 +327,14 @@ func Make(envName string) (Environment, error) {
 				"observation space from type %v", observationSpace.Type())
 		}
 
+	} else if observationSpace.Type() == tupleSpace ||
+		observationSpace.Type() == dictSpace {
+		goObservationSpace, err = SpaceFromPyObject(observationSpace)
+		if err != nil {
+			return nil, fmt.Errorf("make: could not create observation "+
+				"space from type %v: %v", observationSpace.Type(), err)
+		}
+
 	} else {
 		goObservationSpace = nil
 		fmt.Fprintf(os.Stderr, "make: observation space %T not yet "+
@@ -278,11 +377,35 @@ func (g *GymEnv) ContinuousAction() bool {
 }
 
 // Seed seeds the GymEnv and returns the seed. It is equivalent to
-// calling env.seed(seed) in Python's OpenAI Gym.
+// calling env.seed(seed) in Python's OpenAI Gym. Since env.seed was
+// removed in gym 0.26, Seed instead calls env.reset(seed=seed) with
+// gym 0.26 and later, which also resets the environment.
 func (g *GymEnv) Seed(seed int) ([]int, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("seed: %v", err)
+	}
+	if gymNewAPI {
+		kwargs := python.PyDict_New()
+		defer kwargs.DecRef()
+		pySeed := python.PyLong_FromGoInt(seed)
+		defer pySeed.DecRef()
+		python.PyDict_SetItemString(kwargs, "seed", pySeed)
+
+		state, err := g.reset(context.Background(), kwargs)
+		if err != nil {
+			return nil, fmt.Errorf("seed: %v", err)
+		}
+		state.DecRef()
+		return []int{seed}, nil
+	}
+
 	// Get the seed function
 	seedFunc := g.env.GetAttrString("seed")
 	defer seedFunc.DecRef()
+	if seedFunc == nil {
+		return nil, fmt.Errorf("seed: could not get env.seed: %v",
+			pythonError())
+	}
 
 	// Create the Python arguments
 	args := python.PyTuple_New(1)
@@ -308,90 +431,359 @@ func (g *GymEnv) Seed(seed int) ([]int, error) {
 // the next observation, reward, and a flag indicating if the
 // episode has completed. It is equivalent to calling env.step(a) in
 // Python's OpenAI Gym.
+//
+// Actions and observations are represented as single vectors, as
+// described by PointToVec. For example, an action in a DiscreteSpace
+// is a vector holding the integer action, and an observation in a
+// DictSpace is the concatenation of the observations at each key. To
+// step with actions and observations in the structure of their
+// spaces, use StepPoint.
 func (g *GymEnv) Step(a *mat.VecDense) (*mat.VecDense, float64, bool,
 	error) {
-	// Ensure the observation space is a box space. For now, we cannot return
-	// state observations of environments with DictSpace, DiscreteSpace, or
-	// TupleSpace observation spaces.
-	obsSpace, ok := g.ObservationSpace().(*BoxSpace)
-	if !ok {
-		return nil, 0, false, fmt.Errorf("step: can only step in environment "+
-			"with Box observation space, got %T", obsSpace)
+	obs, reward, done, err := g.StepContext(context.Background(), a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+	return obs, reward, done, nil
+}
+
+// StepContext is like Step, but the call to env.step in Python is
+// interrupted if ctx is done before it returns. In that case,
+// StepContext returns ctx.Err(), for example context.DeadlineExceeded
+// if the deadline of ctx has passed, and the GymEnv must be reset
+// before stepping again.
+//
+// The Python call is interrupted by raising KeyboardInterrupt at the
+// next line of Python code it executes after ctx is done, and
+// StepContext returns once the call has unwound. Python code blocked
+// in C code, such as time.sleep or a long NumPy operation, is only
+// interrupted once it returns to Python code. Python code is traced
+// during the call to detect that ctx is done, which makes it slower,
+// so StepContext should only be used when a deadline is needed.
+// Only one StepContext or ResetContext call with a ctx which can be
+// done runs at a time across all GymEnvs, and concurrent calls wait
+// for the running call to return.
+func (g *GymEnv) StepContext(ctx context.Context, a *mat.VecDense) (
+	*mat.VecDense, float64, bool, error) {
+	if g.ActionSpace() == nil {
+		return nil, 0, false, fmt.Errorf("stepContext: cannot step in environment " +
+			"with unsupported action space")
+	}
+
+	action, err := PointFromVec(g.ActionSpace(), a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("stepContext: %v", err)
+	}
+
+	retVal, err := g.step(ctx, action)
+	if err != nil && err == ctx.Err() {
+		return nil, 0, false, err
+	} else if err != nil {
+		return nil, 0, false, fmt.Errorf("stepContext: %v", err)
+	}
+	defer retVal.DecRef()
+
+	// Get the observation vector
+	goObs, err := g.observationVec(python.PyTuple_GetItem(retVal, 0))
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("stepContext: could not decode "+
+			"observation: %v", err)
 	}
 
-	// Ensure the action space is a box space or a discrete space
-	_, boxOk := g.ActionSpace().(*BoxSpace)
-	_, discreteOk := g.ActionSpace().(*DiscreteSpace)
-	if !boxOk && !discreteOk {
-		return nil, 0, false, fmt.Errorf("step: can only step in environment "+
-			"with Box or Discrete action spaces, got %T", g.ActionSpace())
+	goReward, goDone := rewardDone(retVal)
+	if err := g.setInfo(retVal); err != nil {
+		return nil, 0, false, fmt.Errorf("stepContext: %v", err)
 	}
+	return goObs, goReward, goDone, nil
+}
 
-	// Get the step function
-	stepFunc := g.env.GetAttrString("step")
-	defer stepFunc.DecRef()
+// StepPoint is like Step, but takes an action and returns an
+// observation in the structure of their spaces, as described by Space.
+// For example, an action in a DiscreteSpace is an int, and an
+// observation in a DictSpace is a map[string]interface{}.
+func (g *GymEnv) StepPoint(action interface{}) (interface{}, float64, bool,
+	error) {
+	if g.ObservationSpace() == nil || g.ActionSpace() == nil {
+		return nil, 0, false, fmt.Errorf("stepPoint: cannot step in " +
+			"environment with unsupported observation or action space")
+	}
+
+	retVal, err := g.step(context.Background(), action)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("stepPoint: %v", err)
+	}
+	defer retVal.DecRef()
+
+	// Get the observation
+	obs, err := PointFromPython(g.ObservationSpace(),
+		python.PyTuple_GetItem(retVal, 0))
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("stepPoint: could not decode "+
+			"observation: %v", err)
+	}
+
+	goReward, goDone := rewardDone(retVal)
+	if err := g.setInfo(retVal); err != nil {
+		return nil, 0, false, fmt.Errorf("stepPoint: %v", err)
+	}
+	return obs, goReward, goDone, nil
+}
+
+// step calls env.step(action) in Python's OpenAI Gym, where action is
+// a point in the action space. Creates a new python.PyObject reference
+// to the tuple returned by env.step. If ctx is done before env.step
+// returns, then ctx.Err() is returned (see StepContext).
+func (g *GymEnv) step(ctx context.Context, action interface{}) (
+	*python.PyObject, error) {
+	if g.needsReset {
+		return nil, fmt.Errorf("environment must be reset after an " +
+			"interrupted call")
+	}
 
 	// Create the Python arguments
+	pyAction, err := PointToPython(g.ActionSpace(), action)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert action: %v", err)
+	}
 	args := python.PyTuple_New(1)
 	defer args.DecRef()
-	if g.continuousAction {
-		arr, err := F64ToList(a.RawVector().Data)
-		if err != nil {
-			return nil, 0, false, fmt.Errorf("step: could not convert " +
-				"[]float64 to Python List")
-		}
-		python.PyTuple_SetItem(args, 0, arr)
-	} else {
-		python.PyTuple_SetItem(args, 0, python.PyLong_FromDouble(a.AtVec(0)))
-	}
+	python.PyTuple_SetItem(args, 0, pyAction)
 
 	// Call step in Python gym
-	retVal := stepFunc.CallObject(args)
-	defer retVal.DecRef()
+	retVal, err := g.call(ctx, func() *python.PyObject {
+		stepFunc := g.env.GetAttrString("step")
+		defer stepFunc.DecRef()
+
+		return stepFunc.CallObject(args)
+	})
+	if err != nil {
+		return nil, err
+	}
 	if retVal == nil {
-		return nil, 0, false, fmt.Errorf("step: could not step in " +
-			"gym environment")
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("could not step in gym environment")
 	}
+	if !python.PyTuple_Check(retVal) || (python.PyTuple_Size(retVal) != 4 &&
+		python.PyTuple_Size(retVal) != 5) {
+		retVal.DecRef()
+		return nil, fmt.Errorf("env.step returned %v, want a tuple of "+
+			"length 4 or 5", retVal)
+	}
+	return retVal, nil
+}
 
-	// Get the observation vector
-	obs := python.PyTuple_GetItem(retVal, 0)
-	goObsSlice, err := F64SliceFromIter(obs)
-	if err != nil {
-		return nil, 0, false, fmt.Errorf("step: could not decode observation")
+// call calls f, which calls into Python, and returns its result. If ctx
+// is done before f returns, then the Python call is interrupted (see
+// callInterruptible), the GymEnv is marked as needing a reset, and
+// ctx.Err() is returned.
+func (g *GymEnv) call(ctx context.Context, f func() *python.PyObject) (
+	*python.PyObject, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Contexts which can never be done need no interrupt
+	if ctx.Done() == nil {
+		return f(), nil
 	}
-	goObs := mat.NewVecDense(len(goObsSlice), goObsSlice)
 
+	obj, interrupted := callInterruptible(ctx, f)
+	if interrupted {
+		python.PyErr_Clear()
+		g.needsReset = true
+		return nil, ctx.Err()
+	}
+	return obj, nil
+}
+
+// rewardDone returns the reward and done flag from the tuple returned
+// by env.step in Python's OpenAI Gym. Before gym 0.26, the tuple is
+// (observation, reward, done, info). From gym 0.26, it is
+// (observation, reward, terminated, truncated, info), and the episode
+// is done if it is terminated or truncated. Borrows python.PyObject
+// reference.
+func rewardDone(retVal *python.PyObject) (float64, bool) {
 	// Get the reward
 	reward := python.PyTuple_GetItem(retVal, 1)
 	goReward := python.PyFloat_AsDouble(reward)
 
 	// Figure out if the episode is done
-	done := python.PyTuple_GetItem(retVal, 2)
-	goDone := python.Py_True == done
+	goDone := python.PyTuple_GetItem(retVal, 2).IsTrue() == 1
+	if python.PyTuple_Size(retVal) == 5 {
+		truncated := python.PyTuple_GetItem(retVal, 3)
+		goDone = goDone || truncated.IsTrue() == 1
+	}
 
-	return goObs, goReward, goDone, nil
+	return goReward, goDone
+}
+
+// setInfo sets the info dictionary of the GymEnv from the tuple
+// returned by env.step in Python's OpenAI Gym, in which the info
+// dictionary is the last element (see rewardDone). Borrows
+// python.PyObject reference.
+func (g *GymEnv) setInfo(retVal *python.PyObject) error {
+	last := python.PyTuple_Size(retVal) - 1
+	info, err := infoFromPython(python.PyTuple_GetItem(retVal, last))
+	if err != nil {
+		return fmt.Errorf("could not decode info: %v", err)
+	}
+	g.info = info
+	return nil
+}
+
+// Info returns the info dictionary returned by the most recent call
+// to Step or Reset. Since env.reset() in Python only returns an info
+// dictionary in gym 0.26 and later, Info returns an empty map after
+// Reset with older versions of gym.
+func (g *GymEnv) Info() map[string]interface{} {
+	return g.info
+}
+
+// observationVec converts a Python observation to a single vector, as
+// described by PointToVec. If the observation space is not supported,
+// the observation is flattened as a NumPy array. Borrows
+// python.PyObject reference.
+func (g *GymEnv) observationVec(obs *python.PyObject) (*mat.VecDense,
+	error) {
+	if g.ObservationSpace() == nil {
+		data, err := F64SliceFromArray(obs)
+		if err != nil {
+			return nil, err
+		}
+		return mat.NewVecDense(len(data), data), nil
+	}
+
+	point, err := PointFromPython(g.ObservationSpace(), obs)
+	if err != nil {
+		return nil, err
+	}
+	return PointToVec(g.ObservationSpace(), point)
 }
 
 // Reset resets the GymEnv and returns the starting state. It is
-// equivalent to calling env.reset() in Python's OpenAI Gym.
+// equivalent to calling env.reset() in Python's OpenAI Gym. The
+// starting state is represented as a single vector, as described by
+// PointToVec.
 func (g *GymEnv) Reset() (*mat.VecDense, error) {
-	resetFunc := g.env.GetAttrString("reset")
-	defer resetFunc.DecRef()
+	obs, err := g.ResetContext(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+	return obs, nil
+}
 
-	state := resetFunc.CallObject(nil)
+// ResetContext is like Reset, but the call to env.reset in Python is
+// interrupted if ctx is done before it returns. In that case,
+// ResetContext returns ctx.Err() and the GymEnv must be reset again
+// before stepping, as described by StepContext.
+func (g *GymEnv) ResetContext(ctx context.Context) (*mat.VecDense, error) {
+	state, err := g.reset(ctx, nil)
+	if err != nil && err == ctx.Err() {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("resetContext: %v", err)
+	}
 	defer state.DecRef()
 
-	data, err := F64SliceFromIter(state)
+	obs, err := g.observationVec(state)
 	if err != nil {
-		return nil, fmt.Errorf("reset: could not decode Python iterable: %v",
+		return nil, fmt.Errorf("resetContext: could not decode Python "+
+			"iterable: %v", err)
+	}
+	return obs, nil
+}
+
+// ResetPoint is like Reset, but returns the starting state in the
+// structure of the observation space, as described by Space.
+func (g *GymEnv) ResetPoint() (interface{}, error) {
+	if g.ObservationSpace() == nil {
+		return nil, fmt.Errorf("resetPoint: cannot reset environment with " +
+			"unsupported observation space")
+	}
+
+	state, err := g.reset(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("resetPoint: %v", err)
+	}
+	defer state.DecRef()
+
+	obs, err := PointFromPython(g.ObservationSpace(), state)
+	if err != nil {
+		return nil, fmt.Errorf("resetPoint: could not decode observation: %v",
 			err)
 	}
-	return mat.NewVecDense(len(data), data), nil
+	return obs, nil
+}
+
+// reset calls env.reset() in Python's OpenAI Gym and sets the info
+// dictionary of the GymEnv. From gym 0.26, env.reset() returns an
+// (observation, info) tuple. Before gym 0.26, it returns only the
+// observation, and the info dictionary is set to an empty map. Creates
+// a new python.PyObject reference to the starting state. If ctx is
+// done before env.reset returns, then ctx.Err() is returned (see
+// ResetContext).
+//
+// If kwargs is not nil, it holds the keyword arguments of env.reset,
+// such as the seed with gym 0.26 and later (see Seed).
+func (g *GymEnv) reset(ctx context.Context, kwargs *python.PyObject) (
+	*python.PyObject, error) {
+	// The gym version determines the value returned by env.reset()
+	if err := importGym(); err != nil {
+		return nil, err
+	}
+
+	state, err := g.call(ctx, func() *python.PyObject {
+		resetFunc := g.env.GetAttrString("reset")
+		defer resetFunc.DecRef()
+
+		args := python.PyTuple_New(0)
+		defer args.DecRef()
+		return resetFunc.Call(args, kwargs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("could not reset gym environment")
+	}
+	g.needsReset = false
+
+	if !gymNewAPI {
+		g.info = make(map[string]interface{})
+		return state, nil
+	}
+	defer state.DecRef()
+
+	if !python.PyTuple_Check(state) || python.PyTuple_Size(state) != 2 {
+		return nil, fmt.Errorf("env.reset returned %v, want an "+
+			"(observation, info) tuple with gym 0.26 or later", state)
+	}
+
+	info, err := infoFromPython(python.PyTuple_GetItem(state, 1))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode info: %v", err)
+	}
+	g.info = info
+
+	obs := python.PyTuple_GetItem(state, 0)
+	obs.IncRef()
+	return obs, nil
 }
 
 // Close performs cleanup of environment resources. It should be
-// called once the environment is no longer needed.
+// called once the environment is no longer needed. Calling Close more
+// than once has no effect.
 func (g *GymEnv) Close() {
+	if g.closed {
+		return
+	}
+	g.closed = true
+
 	// Remove g from the list of all open environments
 	delete(openEnvironments, g)
 
@@ -429,8 +821,11 @@ func Close() {
 			env.Close()
 		}
 
-		// Decrement the reference count for the gym module
+		// Decrement the reference count for the gym and numpy modules,
+		// which are nil if they were never imported
 		gym.DecRef()
+		dict.DecRef()
+		numpy.DecRef()
 
 		// Decrement spaces counters
 		spaces.DecRef()
@@ -487,6 +882,31 @@ func Example() {
 	Close()
 }
 
+// pythonError returns the pending Python exception as an error and
+// clears it, so that the exception is returned to the caller instead
+// of being printed
+func pythonError() error {
+	if python.PyErr_Occurred() == nil {
+		return fmt.Errorf("no Python exception set")
+	}
+	excType, value, traceback := python.PyErr_NormalizeException(
+		python.PyErr_Fetch())
+	defer excType.DecRef()
+	defer value.DecRef()
+	defer traceback.DecRef()
+
+	name := excType.GetAttrString("__name__")
+	defer name.DecRef()
+	message := value.Str()
+	defer message.DecRef()
+	if name == nil || message == nil {
+		python.PyErr_Clear()
+		return fmt.Errorf("could not get Python exception")
+	}
+	return fmt.Errorf("%v: %v", python.PyUnicode_AsUTF8(name),
+		python.PyUnicode_AsUTF8(message))
+}
+
 // F64SliceFromIter converts a Python iterable to a []float64. Borrows
 // python.PyObject reference.
 //
@@ -515,6 +935,51 @@ func F64SliceFromIter(obj *python.PyObject) ([]float64, error) {
 	return data, nil
 }
 
+// F64SliceFromArray converts a NumPy ndarray, or any other object
+// which NumPy can convert to an ndarray, to a []float64. Arrays with
+// more than one dimension are flattened in row-major order. Borrows
+// python.PyObject reference.
+func F64SliceFromArray(obj *python.PyObject) ([]float64, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("f64SliceFromArray: %v", err)
+	}
+
+	flat := numpy.CallMethodArgs("ravel", obj)
+	defer flat.DecRef()
+	if flat == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("f64SliceFromArray: could not flatten array")
+	}
+
+	// Converting the array to a list of Python floats first is much
+	// faster than iterating over the array itself
+	float64Type := python.PyUnicode_FromString("float64")
+	defer float64Type.DecRef()
+	typed := flat.CallMethodArgs("astype", float64Type)
+	defer typed.DecRef()
+	if typed == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("f64SliceFromArray: could not convert array " +
+			"to float64")
+	}
+	list := typed.CallMethodArgs("tolist")
+	defer list.DecRef()
+	if list == nil || !python.PyList_Check(list) {
+		return nil, fmt.Errorf("f64SliceFromArray: could not convert array " +
+			"to list")
+	}
+
+	data := make([]float64, python.PyList_Size(list))
+	for i := range data {
+		data[i] = python.PyFloat_AsDouble(python.PyList_GetItem(list, i))
+	}
+	return data, nil
+}
+
 // StringSliceFromIter converts a Python iterable to a []string. Borrows
 // python.PyObject reference.
 func StringSliceFromIter(obj *python.PyObject) ([]string, error) {
@@ -588,6 +1053,24 @@ func F64ToList(slice []float64) (*python.PyObject, error) {
 	return list, nil
 }
 
+// IntSliceToTuple converts a []int to a Python Tuple. Creates a new
+// python.PyObject reference.
+func IntSliceToTuple(slice []int) (*python.PyObject, error) {
+	tuple := python.PyTuple_New(len(slice))
+	for i, elem := range slice {
+		n := python.PyTuple_SetItem(tuple, i, python.PyLong_FromGoInt(elem))
+		if n != 0 {
+			if python.PyErr_Occurred() != nil {
+				python.PyErr_Print()
+			}
+			tuple.DecRef()
+			return nil, fmt.Errorf("intSliceToTuple: could not set Python " +
+				"tuple item")
+		}
+	}
+	return tuple, nil
+}
+
 // Print prints a *python.PyObject in a similar way to calling print()
 // in Python.
 func Print(obj *python.PyObject) {
@@ -600,6 +1083,10 @@ func Print(obj *python.PyObject) {
 //
 // If an error occurs, the state of the returned space is not defined.
 func SpaceFromPyObject(obj *python.PyObject) (Space, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("spaceFromPyObject: %v", err)
+	}
+
 	var space Space
 	var err error
 	switch obj.Type() {
@@ -646,27 +1133,18 @@ func Flatten(space Space, x interface{}) ([]float64, error) {
 	discreteSpace, ok := space.(*DiscreteSpace)
 	if ok {
 		onehot := make([]float64, discreteSpace.n)
-		switch t := x.(type) {
-		case int, uint, int64, uint64, int8, uint8, int32, uint32,
-			int16, uint16:
-			position := reflect.ValueOf(x)
-			onehot[position.Int()] = 1.0
-
-		case float64, float32:
-			position := reflect.ValueOf(x)
-			onehot[int(position.Float())] = 1.0
-
-		case *mat.VecDense:
-			position := x.(*mat.VecDense)
-			if position.Len() != 1 {
-				panic("flatten: discrete cannot be multi-dimensional")
-			}
-			onehot[int(position.AtVec(0))] = 1.0
-
-		default:
-			return nil, fmt.Errorf("flatten: type %v is not a point in a "+
-				"DiscreteSpace", t)
+		position, err := discreteIndex(x)
+		if err != nil {
+			return nil, fmt.Errorf("flatten: %v", err)
 		}
+		if !discreteSpace.Contains(position) {
+			return nil, fmt.Errorf("flatten: %v is not a point in the "+
+				"DiscreteSpace", position)
+		}
+
+		// Positions are offset by the start of the space, so that the
+		// first element of the space is encoded with index 0
+		onehot[position-discreteSpace.start] = 1.0
 		return onehot, nil
 	}
 
@@ -695,12 +1173,20 @@ func Flatten(space Space, x interface{}) ([]float64, error) {
 	if ok {
 		var data []float64
 		dict := x.(map[string]interface{})
-		for key := range dict {
-			space, err := dictSpace.At(key)
-			if err != nil {
-				return nil, fmt.Errorf("flatten: %v", err)
+		if len(dict) != dictSpace.Len() {
+			return nil, fmt.Errorf("flatten: input should have same length "+
+				"as DictSpace \n\twant(%v) \n\thave(%v)", dictSpace.Len(),
+				len(dict))
+		}
+
+		// Flatten values in the order of the keys in the DictSpace so
+		// that Unflatten can recover the original point
+		for i, key := range dictSpace.keys {
+			value, ok := dict[key]
+			if !ok {
+				return nil, fmt.Errorf("flatten: key %v not in input", key)
 			}
-			flattenedValue, err := Flatten(space, dict[key])
+			flattenedValue, err := Flatten(dictSpace.values[i], value)
 			if err != nil {
 				return nil, fmt.Errorf("flatten: could not flatten value %v "+
 					"at key %v", dict[key], key)
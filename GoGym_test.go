@@ -1,12 +1,341 @@
 package gogym_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	python "github.com/DataDog/go-python3"
 	"github.com/samuelfneumann/gogym"
 	"gonum.org/v1/gonum/mat"
 )
 
+// gymAPI is prepended to the Python code of test environments. newAPI
+// is whether gym is version 0.26 or later, in which reset returns
+// (observation, info) and step returns (observation, reward,
+// terminated, truncated, info).
+const gymAPI = `
+import gym
+
+newAPI = tuple(int(v) for v in gym.__version__.split(".")[:2]) >= (0, 26)
+`
+
+func TestStepContext(t *testing.T) {
+	// slowEnv sleeps on each step with action 1, never returns from
+	// steps with action 2, and raises a ValueError when steps with
+	// action 3 are interrupted
+	code := gymAPI + `
+import time
+
+class SlowEnv(gym.Env):
+    def __init__(self):
+        self.action_space = gym.spaces.Discrete(4)
+        self.observation_space = gym.spaces.Box(0.0, 1.0, (1,))
+
+    def step(self, action):
+        if action == 1:
+            time.sleep(0.5)
+        if action == 2:
+            n = 0
+            while True:
+                n += 1
+        if action == 3:
+            try:
+                while True:
+                    pass
+            except KeyboardInterrupt:
+                raise ValueError("step failed")
+        if newAPI:
+            return self.observation_space.low, 0.0, False, False, {}
+        return self.observation_space.low, 0.0, False, {}
+
+    def reset(self):
+        if newAPI:
+            return self.observation_space.low, {}
+        return self.observation_space.low
+
+slowEnv = SlowEnv()
+`
+	if python.PyRun_SimpleString(code) != 0 {
+		t.Fatalf("could not create Python environment")
+	}
+	main := python.PyImport_AddModule("__main__")
+	pyEnv := main.GetAttrString("slowEnv")
+	defer pyEnv.DecRef()
+
+	actionSpace, err := gogym.NewDiscrete(4)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	obsSpace, err := gogym.NewBox([]float64{0}, []float64{1}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	env := gogym.New(pyEnv, "SlowEnv", false, actionSpace,
+		obsSpace).(*gogym.GymEnv)
+	defer env.Close()
+
+	if _, err = env.ResetContext(context.Background()); err != nil {
+		t.Fatalf("resetContext: %v", err)
+	}
+
+	// Fast steps finish before the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	fast := mat.NewVecDense(1, []float64{0})
+	if _, _, _, err = env.StepContext(ctx, fast); err != nil {
+		t.Errorf("stepContext: %v", err)
+	}
+
+	// Slow steps time out and require a reset
+	ctx, cancel = context.WithTimeout(context.Background(),
+		50*time.Millisecond)
+	defer cancel()
+	slow := mat.NewVecDense(1, []float64{1})
+	if _, _, _, err = env.StepContext(ctx, slow); err !=
+		context.DeadlineExceeded {
+		t.Errorf("stepContext: want(%v) have(%v)", context.DeadlineExceeded,
+			err)
+	}
+	if _, _, _, err = env.Step(fast); err == nil {
+		t.Errorf("step: expected error before reset")
+	}
+
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if _, _, _, err = env.Step(fast); err != nil {
+		t.Errorf("step: %v", err)
+	}
+
+	// Steps which never return are interrupted
+	ctx, cancel = context.WithTimeout(context.Background(),
+		50*time.Millisecond)
+	defer cancel()
+	busy := mat.NewVecDense(1, []float64{2})
+	start := time.Now()
+	if _, _, _, err = env.StepContext(ctx, busy); err !=
+		context.DeadlineExceeded {
+		t.Errorf("stepContext: want(%v) have(%v)", context.DeadlineExceeded,
+			err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("stepContext: interrupt took %v", elapsed)
+	}
+
+	if _, err = env.ResetContext(context.Background()); err != nil {
+		t.Fatalf("resetContext: %v", err)
+	}
+	if _, _, _, err = env.Step(fast); err != nil {
+		t.Errorf("step: %v", err)
+	}
+
+	// Other Python errors raised after the interrupt are not hidden
+	ctx, cancel = context.WithTimeout(context.Background(),
+		50*time.Millisecond)
+	defer cancel()
+	raising := mat.NewVecDense(1, []float64{3})
+	_, _, _, err = env.StepContext(ctx, raising)
+	if err == nil || err == context.DeadlineExceeded {
+		t.Errorf("stepContext: want Python error have(%v)", err)
+	}
+}
+
+func TestResetInfo(t *testing.T) {
+	// infoEnv returns an info dictionary from reset with gym 0.26 and
+	// later, and ends each episode by truncation after one step
+	code := gymAPI + `
+class InfoEnv(gym.Env):
+    def __init__(self):
+        self.action_space = gym.spaces.Discrete(2)
+        self.observation_space = gym.spaces.Box(0.0, 1.0, (1,))
+
+    def step(self, action):
+        obs = self.observation_space.high
+        if newAPI:
+            return obs, 0.0, False, True, {"step": 1.0}
+        return obs, 0.0, True, {"step": 1.0}
+
+    def reset(self):
+        if newAPI:
+            return self.observation_space.high, {"reset": 1.0}
+        return self.observation_space.high
+
+infoEnv = InfoEnv()
+`
+	if python.PyRun_SimpleString(code) != 0 {
+		t.Fatalf("could not create Python environment")
+	}
+	main := python.PyImport_AddModule("__main__")
+	pyEnv := main.GetAttrString("infoEnv")
+	defer pyEnv.DecRef()
+	newAPI := main.GetAttrString("newAPI")
+	defer newAPI.DecRef()
+
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	obsSpace, err := gogym.NewBox([]float64{0}, []float64{1}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	env := gogym.New(pyEnv, "InfoEnv", false, actionSpace, obsSpace)
+	defer env.Close()
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if obs.Len() != 1 || obs.AtVec(0) != 1 {
+		t.Errorf("reset: want([1]) have(%v)", obs.RawVector().Data)
+	}
+
+	// Before gym 0.26, reset returns no info dictionary
+	if newAPI == python.Py_True {
+		if info := gogym.Info(env); len(info) != 1 || info["reset"] != 1.0 {
+			t.Errorf("info: want(map[reset:1]) have(%v)", info)
+		}
+	} else if info := gogym.Info(env); len(info) != 0 {
+		t.Errorf("info: want(map[]) have(%v)", info)
+	}
+
+	_, _, done, err := env.Step(mat.NewVecDense(1, []float64{0}))
+	if err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if !done {
+		t.Errorf("step: episode should be done when truncated")
+	}
+	if info := gogym.Info(env); len(info) != 1 || info["step"] != 1.0 {
+		t.Errorf("info: want(map[step:1]) have(%v)", info)
+	}
+}
+
+func TestDictSpaceKeyOrder(t *testing.T) {
+	// The keys of orderedDict are not sorted
+	code := `
+from collections import OrderedDict
+import gym
+
+orderedDict = gym.spaces.Dict(OrderedDict([
+    ("velocity", gym.spaces.Box(0.0, 0.0, (1,))),
+    ("angle", gym.spaces.Box(1.0, 1.0, (1,))),
+    ("position", gym.spaces.Box(2.0, 2.0, (1,))),
+]))
+`
+	if python.PyRun_SimpleString(code) != 0 {
+		t.Fatalf("could not create Python space")
+	}
+	main := python.PyImport_AddModule("__main__")
+	pySpace := main.GetAttrString("orderedDict")
+	defer pySpace.DecRef()
+
+	space, err := gogym.SpaceFromPyObject(pySpace)
+	if err != nil {
+		t.Fatalf("spaceFromPyObject: %v", err)
+	}
+	dict, ok := space.(*gogym.DictSpace)
+	if !ok {
+		t.Fatalf("spaceFromPyObject: want *gogym.DictSpace have(%T)", space)
+	}
+
+	// Spaces from Python should keep the Python key order, so that
+	// flattened points line up with those flattened in Python
+	want := []string{"velocity", "angle", "position"}
+	for i, key := range dict.Keys() {
+		if key != want[i] {
+			t.Fatalf("keys: want(%v) have(%v)", want, dict.Keys())
+		}
+	}
+	flat, err := gogym.FlattenSpace(dict)
+	if err != nil {
+		t.Fatalf("flattenSpace: %v", err)
+	}
+	for i, low := range []float64{0, 1, 2} {
+		if flat.Low()[0].AtVec(i) != low {
+			t.Errorf("flattenSpace: want low(%v) at index %v have(%v)", low,
+				i, flat.Low()[0].AtVec(i))
+		}
+	}
+
+	// Converting the space back to Python should keep the key order
+	pyDict, err := gogym.ToPython(dict)
+	if err != nil {
+		t.Fatalf("toPython: %v", err)
+	}
+	defer pyDict.DecRef()
+	roundTrip, err := gogym.SpaceFromPyObject(pyDict)
+	if err != nil {
+		t.Fatalf("spaceFromPyObject: %v", err)
+	}
+	for i, key := range roundTrip.(*gogym.DictSpace).Keys() {
+		if key != want[i] {
+			t.Errorf("toPython: want keys(%v) have(%v)", want,
+				roundTrip.(*gogym.DictSpace).Keys())
+			break
+		}
+	}
+}
+
+func TestMakeTupleObservation(t *testing.T) {
+	// Blackjack has observations in Tuple(Discrete(32), Discrete(11),
+	// Discrete(2))
+	env, err := gogym.Make("Blackjack-v0")
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+	defer env.Close()
+
+	tuple, ok := env.ObservationSpace().(*gogym.TupleSpace)
+	if !ok {
+		t.Fatalf("make: want observation space *gogym.TupleSpace have(%T)",
+			env.ObservationSpace())
+	}
+	if tuple.Len() != 3 {
+		t.Errorf("make: want tuple of length 3 have(%v)", tuple.Len())
+	}
+	for i := 0; i < tuple.Len(); i++ {
+		if _, ok := tuple.At(i).(*gogym.DiscreteSpace); !ok {
+			t.Errorf("make: want *gogym.DiscreteSpace at index %v have(%T)",
+				i, tuple.At(i))
+		}
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	point, err := gogym.PointFromVec(tuple, obs)
+	if err != nil {
+		t.Fatalf("pointFromVec: %v", err)
+	}
+	if !tuple.Contains(point) {
+		t.Errorf("reset: observation %v not in observation space",
+			obs.RawVector().Data)
+	}
+
+	point, err = env.(*gogym.GymEnv).ResetPoint()
+	if err != nil {
+		t.Fatalf("resetPoint: %v", err)
+	}
+	if _, ok := point.([]interface{}); !ok || !tuple.Contains(point) {
+		t.Errorf("resetPoint: observation %v not in observation space",
+			point)
+	}
+}
+
+func TestMakeError(t *testing.T) {
+	// The Python exception should be returned rather than printed, and
+	// cleared so that it does not affect later calls
+	if _, err := gogym.Make("NoSuchEnvironment-v0"); err == nil {
+		t.Errorf("make: expected error for unregistered environment")
+	}
+	if python.PyErr_Occurred() != nil {
+		t.Errorf("make: Python exception not cleared")
+	}
+}
+
 func TestMake(t *testing.T) {
 
 	tests := []string{
@@ -47,8 +376,8 @@ func TestMake(t *testing.T) {
 			t.Errorf("seed: %v", err)
 		}
 
-		// Take an environmental step
-		_, _, _, err = env.Step(mat.NewVecDense(1, []float64{0.0}))
+		// Take an environmental step with an action of the right size
+		_, _, _, err = env.Step(env.ActionSpace().Sample()[0])
 		if err != nil {
 			t.Errorf("step: %v", err)
 		}
@@ -0,0 +1,198 @@
+package gogym
+
+import (
+	"fmt"
+
+	python "github.com/DataDog/go-python3"
+)
+
+// InfoEnvironment is an Environment which returns the info dictionary
+// of its most recent step or reset. GymEnv implements InfoEnvironment,
+// as do the wrappers in the wrappers package, which forward the info
+// dictionary of the environment they wrap.
+type InfoEnvironment interface {
+	Environment
+
+	// Info returns the info dictionary returned by the most recent
+	// call to Step or Reset. Environments whose Reset returns no info
+	// dictionary, such as GymEnvs using gym older than 0.26, should
+	// return an empty map after Reset. The returned map should not be
+	// modified.
+	Info() map[string]interface{}
+}
+
+// Info returns the info dictionary of the most recent call to Step or
+// Reset in env. If env does not implement InfoEnvironment, then Info
+// returns an empty map.
+func Info(env Environment) map[string]interface{} {
+	if infoEnv, ok := env.(InfoEnvironment); ok {
+		return infoEnv.Info()
+	}
+	return make(map[string]interface{})
+}
+
+// ActionMaskKey is the key in the info dictionary of an environment at
+// which the mask of legal actions is stored, as in gym's Taxi
+// environment
+const ActionMaskKey = "action_mask"
+
+// ActionMask returns the mask of legal actions for the next step in
+// env, read from the environment's info dictionary at ActionMaskKey.
+// Element i of the mask is true if the i-th action of the action
+// space is legal. If the info dictionary holds no action mask, then
+// ActionMask returns a nil mask and a nil error, and all actions
+// should be taken to be legal, as they are if env does not implement
+// InfoEnvironment.
+func ActionMask(env Environment) ([]bool, error) {
+	value, ok := Info(env)[ActionMaskKey]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case []bool:
+		return v, nil
+
+	case []float64:
+		mask := make([]bool, len(v))
+		for i := range v {
+			mask[i] = v[i] != 0
+		}
+		return mask, nil
+
+	case []interface{}:
+		mask := make([]bool, len(v))
+		for i := range v {
+			switch elem := v[i].(type) {
+			case bool:
+				mask[i] = elem
+			case int:
+				mask[i] = elem != 0
+			case float64:
+				mask[i] = elem != 0
+			default:
+				return nil, fmt.Errorf("actionMask: illegal mask element %v "+
+					"of type %T", elem, elem)
+			}
+		}
+		return mask, nil
+	}
+
+	return nil, fmt.Errorf("actionMask: illegal mask of type %T", value)
+}
+
+// infoFromPython converts a Python info dictionary, as returned by
+// env.step in Python's OpenAI Gym, to a Go map. Only string keys are
+// kept. Borrows python.PyObject reference.
+func infoFromPython(obj *python.PyObject) (map[string]interface{}, error) {
+	info := make(map[string]interface{})
+	if obj == nil || obj == python.Py_None {
+		return info, nil
+	}
+	if !python.PyDict_Check(obj) {
+		return nil, fmt.Errorf("infoFromPython: info is not a dict")
+	}
+
+	var pos int
+	var key, value *python.PyObject
+	for python.PyDict_Next(obj, &pos, &key, &value) {
+		if !python.PyUnicode_Check(key) {
+			continue
+		}
+
+		goValue, err := ValueFromPython(value)
+		if err != nil {
+			return nil, fmt.Errorf("infoFromPython: key %v: %v",
+				python.PyUnicode_AsUTF8(key), err)
+		}
+		info[python.PyUnicode_AsUTF8(key)] = goValue
+	}
+	return info, nil
+}
+
+// ValueFromPython converts a Python value to its closest Go
+// equivalent:
+//
+//	Python					Go
+//	None					nil
+//	bool					bool
+//	int						int
+//	float					float64
+//	str						string
+//	numpy.ndarray			[]float64, flattened in row-major order
+//	numpy scalar			the Go equivalent of its Python value
+//	list, tuple				[]interface{}
+//	dict					map[string]interface{}
+//
+// Any other Python value is converted to its string representation.
+// Borrows python.PyObject reference.
+func ValueFromPython(obj *python.PyObject) (interface{}, error) {
+	switch {
+	case obj == nil:
+		return nil, fmt.Errorf("valueFromPython: nil Python object")
+
+	case obj == python.Py_None:
+		return nil, nil
+
+	case python.PyBool_Check(obj):
+		return obj == python.Py_True, nil
+
+	case python.PyLong_Check(obj):
+		return python.PyLong_AsLong(obj), nil
+
+	case python.PyFloat_Check(obj):
+		return python.PyFloat_AsDouble(obj), nil
+
+	case python.PyUnicode_Check(obj):
+		return python.PyUnicode_AsUTF8(obj), nil
+
+	case python.PyList_Check(obj), python.PyTuple_Check(obj):
+		values := make([]interface{}, obj.Length())
+		for i := range values {
+			var item *python.PyObject
+			if python.PyList_Check(obj) {
+				item = python.PyList_GetItem(obj, i)
+			} else {
+				item = python.PyTuple_GetItem(obj, i)
+			}
+
+			value, err := ValueFromPython(item)
+			if err != nil {
+				return nil, fmt.Errorf("valueFromPython: index %v: %v", i,
+					err)
+			}
+			values[i] = value
+		}
+		return values, nil
+
+	case python.PyDict_Check(obj):
+		dict, err := infoFromPython(obj)
+		if err != nil {
+			return nil, fmt.Errorf("valueFromPython: %v", err)
+		}
+		return dict, nil
+
+	case obj.HasAttrString("ndim") && obj.HasAttrString("item"):
+		// NumPy arrays and scalars
+		ndim := obj.GetAttrString("ndim")
+		defer ndim.DecRef()
+		if python.PyLong_AsLong(ndim) > 0 {
+			return F64SliceFromArray(obj)
+		}
+
+		item := obj.CallMethodArgs("item")
+		defer item.DecRef()
+		if item == nil {
+			if python.PyErr_Occurred() != nil {
+				python.PyErr_Print()
+			}
+			return nil, fmt.Errorf("valueFromPython: could not convert " +
+				"NumPy scalar")
+		}
+		return ValueFromPython(item)
+	}
+
+	str := obj.Str()
+	defer str.DecRef()
+	return python.PyUnicode_AsUTF8(str), nil
+}
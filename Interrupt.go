@@ -0,0 +1,111 @@
+package gogym
+
+// #include "Python.h"
+//
+// // interruptFlag is set from Go when the context of a call into
+// // Python is done, and is read by interruptTrace while the call runs
+// static int interruptFlag;
+//
+// // The trace function and its object which were installed before the
+// // interruptible call started, restored once it returns
+// static Py_tracefunc previousTrace;
+// static PyObject *previousTraceObj;
+//
+// // interruptTrace is a trace function which raises KeyboardInterrupt
+// // on the next trace event after interruptFlag is set, which unwinds
+// // the Python call without needing the main thread to handle signals
+// static int interruptTrace(PyObject *obj, PyFrameObject *frame,
+//                           int what, PyObject *arg) {
+//     if (__atomic_load_n(&interruptFlag, __ATOMIC_SEQ_CST)) {
+//         PyErr_SetString(PyExc_KeyboardInterrupt,
+//                         "call interrupted: context done");
+//         return -1;
+//     }
+//     return 0;
+// }
+//
+// static void startInterruptible(void) {
+//     PyThreadState *tstate = PyThreadState_Get();
+//     previousTrace = tstate->c_tracefunc;
+//     previousTraceObj = tstate->c_traceobj;
+//     Py_XINCREF(previousTraceObj);
+//
+//     __atomic_store_n(&interruptFlag, 0, __ATOMIC_SEQ_CST);
+//     PyEval_SetTrace(interruptTrace, NULL);
+// }
+//
+// static void interrupt(void) {
+//     __atomic_store_n(&interruptFlag, 1, __ATOMIC_SEQ_CST);
+// }
+//
+// static int stopInterruptible(void) {
+//     PyEval_SetTrace(previousTrace, previousTraceObj);
+//     Py_XDECREF(previousTraceObj);
+//     previousTrace = NULL;
+//     previousTraceObj = NULL;
+//
+//     return __atomic_exchange_n(&interruptFlag, 0, __ATOMIC_SEQ_CST);
+// }
+import "C"
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	python "github.com/DataDog/go-python3"
+)
+
+// interruptMu ensures that only one interruptible call runs at a time,
+// since the interrupt flag and previous trace function are shared by
+// all calls
+var interruptMu sync.Mutex
+
+// callInterruptible calls f, which calls into Python, and returns its
+// result and whether f was interrupted because ctx was done before f
+// returned.
+//
+// While f runs, a trace function is installed in the Python
+// interpreter which raises KeyboardInterrupt on the next line of
+// Python code executed after ctx is done, so that f returns nil. The
+// call runs on the calling goroutine and its OS thread, which holds
+// the GIL, and only the interrupt flag is set from another goroutine.
+// Python code blocked in C, for example in time.sleep, is interrupted
+// only once it returns to Python code.
+//
+// Only one call to callInterruptible runs at a time, and concurrent
+// calls block until the running call returns. The result is reported
+// as interrupted only if f returned nil with the KeyboardInterrupt
+// raised by the trace function set, so that other Python errors are
+// left for the caller to handle.
+func callInterruptible(ctx context.Context,
+	f func() *python.PyObject) (*python.PyObject, bool) {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	C.startInterruptible()
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			C.interrupt()
+		case <-stop:
+		}
+	}()
+
+	obj := f()
+
+	// Wait for the watcher to finish so that it cannot set the flag
+	// for a later call
+	close(stop)
+	<-stopped
+
+	interrupted := C.stopInterruptible() != 0
+	return obj, interrupted && obj == nil && python.PyErr_Occurred() != nil &&
+		python.PyErr_ExceptionMatches(python.PyExc_KeyboardInterrupt)
+}
@@ -0,0 +1,384 @@
+package gogym
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	python "github.com/DataDog/go-python3"
+	"gonum.org/v1/gonum/mat"
+)
+
+// PointSampler is implemented by spaces which can sample points in
+// the structure of the space, as described by Space. All spaces in
+// this package implement PointSampler.
+type PointSampler interface {
+	// SamplePoint takes a sample from within the space bounds and
+	// returns it as a point in the structure of the space. The
+	// returned point is accepted by Contains, Flatten, and
+	// PointToPython.
+	SamplePoint() interface{}
+}
+
+// SamplePoint takes a sample from within the bounds of space and
+// returns it as a point in the structure of the space. If space does
+// not implement PointSampler, then the vectors returned by its Sample
+// method are converted to a point with PointFromVec.
+func SamplePoint(space Space) (interface{}, error) {
+	if sampler, ok := space.(PointSampler); ok {
+		return sampler.SamplePoint(), nil
+	}
+
+	var data []float64
+	for _, sample := range space.Sample() {
+		data = append(data, sample.RawVector().Data...)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("samplePoint: space %T has no samples",
+			space)
+	}
+	point, err := PointFromVec(space, mat.NewVecDense(len(data), data))
+	if err != nil {
+		return nil, fmt.Errorf("samplePoint: %v", err)
+	}
+	return point, nil
+}
+
+// PointToVec converts a point in space to a single vector, which
+// concatenates the elements of each BoxSpace and the integer of each
+// DiscreteSpace in the point, in recursive order.
+func PointToVec(space Space, x interface{}) (*mat.VecDense, error) {
+	data, err := pointToSlice(space, x)
+	if err != nil {
+		return nil, fmt.Errorf("pointToVec: %v", err)
+	}
+	return mat.NewVecDense(len(data), data), nil
+}
+
+// pointToSlice converts a point in space to a []float64 as described
+// by PointToVec
+func pointToSlice(space Space, x interface{}) ([]float64, error) {
+	switch s := space.(type) {
+	case *BoxSpace:
+		switch t := x.(type) {
+		case *mat.VecDense:
+			data := make([]float64, t.Len())
+			copy(data, t.RawVector().Data)
+			return data, nil
+
+		case []float64:
+			data := make([]float64, len(t))
+			copy(data, t)
+			return data, nil
+		}
+		return nil, fmt.Errorf("type %T is not a point in a BoxSpace", x)
+
+	case *DiscreteSpace:
+		i, err := discreteIndex(x)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{float64(i)}, nil
+
+	case *TupleSpace:
+		tuple, ok := x.([]interface{})
+		if !ok || len(tuple) != s.Len() {
+			return nil, fmt.Errorf("%v is not a point in a TupleSpace of "+
+				"length %v", x, s.Len())
+		}
+		var data []float64
+		for i := range tuple {
+			subData, err := pointToSlice(s.At(i), tuple[i])
+			if err != nil {
+				return nil, fmt.Errorf("index %v: %v", i, err)
+			}
+			data = append(data, subData...)
+		}
+		return data, nil
+
+	case *DictSpace:
+		dict, ok := x.(map[string]interface{})
+		if !ok || len(dict) != s.Len() {
+			return nil, fmt.Errorf("%v is not a point in a DictSpace of "+
+				"length %v", x, s.Len())
+		}
+		var data []float64
+		for i, key := range s.keys {
+			value, ok := dict[key]
+			if !ok {
+				return nil, fmt.Errorf("key %v not in point", key)
+			}
+			subData, err := pointToSlice(s.values[i], value)
+			if err != nil {
+				return nil, fmt.Errorf("key %v: %v", key, err)
+			}
+			data = append(data, subData...)
+		}
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("space type %T not yet implemented", space)
+}
+
+// PointFromVec is the inverse of PointToVec. It converts a vector which
+// concatenates the elements of each BoxSpace and the integer of each
+// DiscreteSpace in space into a point in the structure of space.
+func PointFromVec(space Space, v *mat.VecDense) (interface{}, error) {
+	data := v.RawVector().Data
+	point, n, err := pointFromSlice(space, data)
+	if err != nil {
+		return nil, fmt.Errorf("pointFromVec: %v", err)
+	}
+	if n != len(data) {
+		return nil, fmt.Errorf("pointFromVec: vector of length %v is too "+
+			"long for space, want length %v", len(data), n)
+	}
+	return point, nil
+}
+
+// pointFromSlice converts the start of data to a point in space and
+// returns the point along with the number of elements of data used
+func pointFromSlice(space Space, data []float64) (interface{}, int, error) {
+	switch s := space.(type) {
+	case *BoxSpace:
+		n := s.low.Len()
+		if len(data) < n {
+			return nil, 0, fmt.Errorf("vector too short for BoxSpace of "+
+				"length %v", n)
+		}
+		vec := make([]float64, n)
+		copy(vec, data[:n])
+		return mat.NewVecDense(n, vec), n, nil
+
+	case *DiscreteSpace:
+		if len(data) < 1 {
+			return nil, 0, fmt.Errorf("vector too short for DiscreteSpace")
+		}
+		return int(data[0]), 1, nil
+
+	case *TupleSpace:
+		tuple := make([]interface{}, s.Len())
+		start := 0
+		for i := range tuple {
+			value, n, err := pointFromSlice(s.At(i), data[start:])
+			if err != nil {
+				return nil, 0, fmt.Errorf("index %v: %v", i, err)
+			}
+			tuple[i] = value
+			start += n
+		}
+		return tuple, start, nil
+
+	case *DictSpace:
+		dict := make(map[string]interface{}, s.Len())
+		start := 0
+		for i, key := range s.keys {
+			value, n, err := pointFromSlice(s.values[i], data[start:])
+			if err != nil {
+				return nil, 0, fmt.Errorf("key %v: %v", key, err)
+			}
+			dict[key] = value
+			start += n
+		}
+		return dict, start, nil
+	}
+
+	return nil, 0, fmt.Errorf("space type %T not yet implemented", space)
+}
+
+// PointToPython converts a point in space to its Python equivalent:
+// a numpy.ndarray of the space's shape and data type for a BoxSpace,
+// an int for a DiscreteSpace, a tuple for a TupleSpace, and a dict
+// for a DictSpace. Creates a new python.PyObject reference.
+func PointToPython(space Space, x interface{}) (*python.PyObject, error) {
+	switch s := space.(type) {
+	case *BoxSpace:
+		data, err := pointToSlice(s, x)
+		if err != nil {
+			return nil, fmt.Errorf("pointToPython: %v", err)
+		}
+		if len(data) != s.low.Len() {
+			return nil, fmt.Errorf("pointToPython: point of length %v not "+
+				"in BoxSpace of length %v", len(data), s.low.Len())
+		}
+		arr, err := s.ndarray(data)
+		if err != nil {
+			return nil, fmt.Errorf("pointToPython: %v", err)
+		}
+		return arr, nil
+
+	case *DiscreteSpace:
+		i, err := discreteIndex(x)
+		if err != nil {
+			return nil, fmt.Errorf("pointToPython: %v", err)
+		}
+		return python.PyLong_FromGoInt(i), nil
+
+	case *TupleSpace:
+		tuple, ok := x.([]interface{})
+		if !ok || len(tuple) != s.Len() {
+			return nil, fmt.Errorf("pointToPython: %v is not a point in a "+
+				"TupleSpace of length %v", x, s.Len())
+		}
+
+		pyTuple := python.PyTuple_New(len(tuple))
+		for i := range tuple {
+			value, err := PointToPython(s.At(i), tuple[i])
+			if err != nil {
+				pyTuple.DecRef()
+				return nil, fmt.Errorf("pointToPython: index %v: %v", i, err)
+			}
+
+			// PyTuple_SetItem steals the reference to value
+			python.PyTuple_SetItem(pyTuple, i, value)
+		}
+		return pyTuple, nil
+
+	case *DictSpace:
+		dict, ok := x.(map[string]interface{})
+		if !ok || len(dict) != s.Len() {
+			return nil, fmt.Errorf("pointToPython: %v is not a point in a "+
+				"DictSpace of length %v", x, s.Len())
+		}
+
+		pyDict := python.PyDict_New()
+		for i, key := range s.keys {
+			point, ok := dict[key]
+			if !ok {
+				pyDict.DecRef()
+				return nil, fmt.Errorf("pointToPython: key %v not in point",
+					key)
+			}
+			value, err := PointToPython(s.values[i], point)
+			if err != nil {
+				pyDict.DecRef()
+				return nil, fmt.Errorf("pointToPython: key %v: %v", key, err)
+			}
+			python.PyDict_SetItemString(pyDict, key, value)
+			value.DecRef()
+		}
+		return pyDict, nil
+	}
+
+	return nil, fmt.Errorf("pointToPython: space type %T not yet "+
+		"implemented", space)
+}
+
+// PointFromPython converts a Python object, such as an observation
+// returned by a gym environment, to a point in space. Borrows
+// python.PyObject reference.
+func PointFromPython(space Space, obj *python.PyObject) (interface{},
+	error) {
+	if obj == nil {
+		return nil, fmt.Errorf("pointFromPython: nil Python object")
+	}
+
+	switch s := space.(type) {
+	case *BoxSpace:
+		data, err := F64SliceFromArray(obj)
+		if err != nil {
+			return nil, fmt.Errorf("pointFromPython: %v", err)
+		}
+		return mat.NewVecDense(len(data), data), nil
+
+	case *DiscreteSpace:
+		i := python.PyLong_AsLong(obj)
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+			return nil, fmt.Errorf("pointFromPython: could not convert " +
+				"Python object to int")
+		}
+		return i, nil
+
+	case *TupleSpace:
+		var getItem func(*python.PyObject, int) *python.PyObject
+		switch {
+		case python.PyTuple_Check(obj):
+			getItem = python.PyTuple_GetItem
+		case python.PyList_Check(obj):
+			getItem = python.PyList_GetItem
+		default:
+			return nil, fmt.Errorf("pointFromPython: Python object is not " +
+				"a tuple")
+		}
+		if obj.Length() != s.Len() {
+			return nil, fmt.Errorf("pointFromPython: tuple of length %v "+
+				"not in TupleSpace of length %v", obj.Length(), s.Len())
+		}
+
+		tuple := make([]interface{}, s.Len())
+		for i := range tuple {
+			value, err := PointFromPython(s.At(i), getItem(obj, i))
+			if err != nil {
+				return nil, fmt.Errorf("pointFromPython: index %v: %v", i,
+					err)
+			}
+			tuple[i] = value
+		}
+		return tuple, nil
+
+	case *DictSpace:
+		if !python.PyDict_Check(obj) {
+			return nil, fmt.Errorf("pointFromPython: Python object is not " +
+				"a dict")
+		}
+
+		dict := make(map[string]interface{}, s.Len())
+		for i, key := range s.keys {
+			item := python.PyDict_GetItemString(obj, key)
+			if item == nil {
+				return nil, fmt.Errorf("pointFromPython: key %v not in "+
+					"Python dict", key)
+			}
+			value, err := PointFromPython(s.values[i], item)
+			if err != nil {
+				return nil, fmt.Errorf("pointFromPython: key %v: %v", key,
+					err)
+			}
+			dict[key] = value
+		}
+		return dict, nil
+	}
+
+	return nil, fmt.Errorf("pointFromPython: space type %T not yet "+
+		"implemented", space)
+}
+
+// discreteIndex converts a point in a DiscreteSpace to an int. The
+// point may be any integer or floating point type with an integral
+// value, or a *mat.VecDense or []float64 with a single element.
+func discreteIndex(x interface{}) (int, error) {
+	var value float64
+	switch t := x.(type) {
+	case int, int64, int32, int16, int8:
+		return int(reflect.ValueOf(x).Int()), nil
+
+	case uint, uint64, uint32, uint16, uint8:
+		return int(reflect.ValueOf(x).Uint()), nil
+
+	case float64, float32:
+		value = reflect.ValueOf(x).Float()
+
+	case *mat.VecDense:
+		if t.Len() != 1 {
+			return 0, fmt.Errorf("discrete point cannot be " +
+				"multi-dimensional")
+		}
+		value = t.AtVec(0)
+
+	case []float64:
+		if len(t) != 1 {
+			return 0, fmt.Errorf("discrete point cannot be " +
+				"multi-dimensional")
+		}
+		value = t[0]
+
+	default:
+		return 0, fmt.Errorf("type %T is not a point in a DiscreteSpace", x)
+	}
+
+	if math.Trunc(value) != value {
+		return 0, fmt.Errorf("%v is not an integer", value)
+	}
+	return int(value), nil
+}
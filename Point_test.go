@@ -0,0 +1,115 @@
+package gogym_test
+
+import (
+	"testing"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestSamplePoint(t *testing.T) {
+	space := nestedSpace(t)
+	space.Seed(3)
+
+	for i := 0; i < 50; i++ {
+		point, err := gogym.SamplePoint(space)
+		if err != nil {
+			t.Fatalf("samplePoint: %v", err)
+		}
+		if !space.Contains(point) {
+			t.Fatalf("contains: sample %v not in space", point)
+		}
+
+		flat, err := gogym.Flatten(space, point)
+		if err != nil {
+			t.Fatalf("flatten: %v", err)
+		}
+
+		// Round trip through the vector representation
+		vec, err := gogym.PointToVec(space, point)
+		if err != nil {
+			t.Fatalf("pointToVec: %v", err)
+		}
+		if vec.Len() != 4+1+1+1 {
+			t.Errorf("pointToVec: want(%v) elements have(%v)", 4+1+1+1,
+				vec.Len())
+		}
+		fromVec, err := gogym.PointFromVec(space, vec)
+		if err != nil {
+			t.Fatalf("pointFromVec: %v", err)
+		}
+		if !space.Contains(fromVec) {
+			t.Errorf("pointFromVec: point %v not in space", fromVec)
+		}
+		reflat, err := gogym.Flatten(space, fromVec)
+		if err != nil {
+			t.Fatalf("flatten: %v", err)
+		}
+		if !floats.Equal(flat, reflat) {
+			t.Errorf("pointFromVec: round trip failed \n\twant(%v) "+
+				"\n\thave(%v)", flat, reflat)
+		}
+	}
+
+	// The vector representation of a point should have the same layout
+	// as the samples returned by Sample
+	samples := space.Sample()
+	if len(samples) != 4 {
+		t.Errorf("sample: want(4) vectors have(%v)", len(samples))
+	}
+
+	// Spaces which are not PointSamplers and are not supported by
+	// PointFromVec cannot be sampled as points
+	custom := struct{ gogym.Space }{space}
+	if _, ok := interface{}(custom).(gogym.PointSampler); ok {
+		t.Fatalf("custom space should not be a PointSampler")
+	}
+	if _, err := gogym.SamplePoint(custom); err == nil {
+		t.Errorf("samplePoint: expected error for custom space")
+	}
+}
+
+func TestPointPython(t *testing.T) {
+	space := nestedSpace(t)
+
+	point, err := gogym.SamplePoint(space)
+	if err != nil {
+		t.Fatalf("samplePoint: %v", err)
+	}
+	pyPoint, err := gogym.PointToPython(space, point)
+	if err != nil {
+		t.Fatalf("pointToPython: %v", err)
+	}
+	defer pyPoint.DecRef()
+
+	// The Python point should be in the Python space
+	pySpace, err := gogym.ToPython(space)
+	if err != nil {
+		t.Fatalf("toPython: %v", err)
+	}
+	defer pySpace.DecRef()
+	contains := pySpace.CallMethodArgs("contains", pyPoint)
+	defer contains.DecRef()
+	if contains != python.Py_True {
+		t.Errorf("pointToPython: point not in Python space")
+	}
+
+	fromPython, err := gogym.PointFromPython(space, pyPoint)
+	if err != nil {
+		t.Fatalf("pointFromPython: %v", err)
+	}
+
+	flat, err := gogym.Flatten(space, point)
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	reflat, err := gogym.Flatten(space, fromPython)
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	if !floats.Equal(flat, reflat) {
+		t.Errorf("pointFromPython: round trip failed \n\twant(%v) "+
+			"\n\thave(%v)", flat, reflat)
+	}
+}
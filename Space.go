@@ -2,24 +2,98 @@ package gogym
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	python "github.com/DataDog/go-python3"
+	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/mat"
 )
 
+// seeds generates the initial seeds of newly constructed spaces. By
+// default, it is seeded with the current time, so that newly
+// constructed spaces produce different samples between runs. To make
+// sampling reproducible, use SetSeed before constructing any spaces.
+var seeds = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(uint64(time.Now().UnixNano())))}
+
+// SetSeed seeds the package-level source from which all subsequently
+// constructed spaces draw the initial seeds of their samplers, and
+// from which NewSeed draws seeds. After calling SetSeed with the same
+// seed, spaces constructed in the same order produce identical
+// sequences of samples.
+func SetSeed(seed uint64) {
+	seeds.Lock()
+	defer seeds.Unlock()
+	seeds.Seed(seed)
+}
+
+// NewSeed returns a new seed drawn from the package-level source
+// which seeds newly constructed spaces. Random number generators
+// outside this package, such as those of wrappers, should be seeded
+// with NewSeed so that calling SetSeed also makes them reproducible.
+func NewSeed() uint64 {
+	return newSeed()
+}
+
+// newSeed returns the initial seed for a newly constructed space
+func newSeed() uint64 {
+	seeds.Lock()
+	defer seeds.Unlock()
+	return seeds.Uint64()
+}
+
+// subseeds derives n independent seeds from a single seed. Composite
+// spaces use subseeds to seed each of their sub-spaces so that sibling
+// sub-spaces do not produce correlated samples, similar to how
+// gym.spaces.Dict and gym.spaces.Tuple seed their sub-spaces.
+func subseeds(seed uint64, n int) []uint64 {
+	rng := rand.New(rand.NewSource(seed))
+	derived := make([]uint64, n)
+	for i := range derived {
+		derived[i] = rng.Uint64()
+	}
+	return derived
+}
+
 // Space describes a space of actions, observations, etc. It is the Go
 // equivalent of a description of the gym.spaces package. Each space
-// must be constructed from its Python equivalent. That is, the
-// constructor for each space takes in the Python version of the space
-// and converts it to a Go version.
+// can be constructed from its Python equivalent (e.g. NewBoxSpace),
+// in which case the constructor takes in the Python version of the
+// space and converts it to a Go version, or directly from Go values
+// (e.g. NewBox), in which case no Python object is needed.
+//
+// A point is a single element of a space, such as an action or an
+// observation, kept in the structure of its space. Points are
+// represented with the following concrete types, which are returned
+// by SamplePoint, Unflatten, and PointFromPython:
+//
+//	Space			Type
+//	BoxSpace		*mat.VecDense
+//	DiscreteSpace	int
+//	TupleSpace		[]interface{}
+//	DictSpace		map[string]interface{}
+//
+// Any point of this form is accepted by the Contains method of its
+// space, Flatten, and PointToPython. For use with the Step and Reset
+// methods of an Environment, a point can also be represented as a
+// single *mat.VecDense, which concatenates the elements of each
+// BoxSpace and the integer of each DiscreteSpace in the point in
+// recursive order. This is the same order in which Sample returns the
+// vectors of a composite space. PointToVec and PointFromVec convert
+// between the two representations.
 type Space interface {
-	// Sample takes a sample from within the spaces bounds
+	// Sample takes a sample from within the spaces bounds. Composite
+	// spaces return the samples of all sub-spaces in recursive order.
 	Sample() []*mat.VecDense
 
 	// Contains returns whether x is in the space
 	Contains(x interface{}) bool
 
-	// Seed seeds the sampler for the space
+	// Seed seeds the sampler for the space. Composite spaces derive
+	// an independent seed for each sub-space from the argument seed.
 	Seed(uint64)
 
 	// Low returns the lower bounds of the space
@@ -32,6 +106,9 @@ type Space interface {
 // FromPythonSpace converts a Python Open AI Gym space to a Go
 // equivalent
 func FromPythonSpace(space *python.PyObject) (Space, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("fromPythonSpace: %v", err)
+	}
 	var value Space
 	var err error
 	switch space.Type() {
@@ -44,8 +121,8 @@ func FromPythonSpace(space *python.PyObject) (Space, error) {
 	case dictSpace:
 		value, err = NewDictSpace(space)
 
-	// case tupleSpace:
-	// 	value, err = NewTupleSpace(space)
+	case tupleSpace:
+		value, err = NewTupleSpace(space)
 
 	default:
 		return nil, fmt.Errorf("fromPythonSpace: space %v not yet "+
@@ -57,3 +134,34 @@ func FromPythonSpace(space *python.PyObject) (Space, error) {
 	}
 	return value, nil
 }
+
+// ToPython converts a Go space to its Python Open AI Gym equivalent,
+// constructing the gym.spaces object from the Go values of the space.
+// Converting the returned Python space back to Go with FromPythonSpace
+// results in a space equal to the argument space. Creates a new
+// python.PyObject reference.
+func ToPython(space Space) (*python.PyObject, error) {
+	var value *python.PyObject
+	var err error
+	switch s := space.(type) {
+	case *BoxSpace:
+		value, err = s.toPython()
+
+	case *DiscreteSpace:
+		value, err = s.toPython()
+
+	case *DictSpace:
+		value, err = s.toPython()
+
+	case *TupleSpace:
+		value, err = s.toPython()
+
+	default:
+		return nil, fmt.Errorf("toPython: space %T not yet implemented",
+			space)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("toPython: could not convert space: %v", err)
+	}
+	return value, nil
+}
@@ -0,0 +1,593 @@
+package gogym_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewBox(t *testing.T) {
+	low := []float64{-1.0, 0.0, -2.0, math.Inf(-1)}
+	high := []float64{1.0, 5.0, -1.0, 3.0}
+
+	box, err := gogym.NewBox(low, high, []int{2, 2})
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+
+	if box.PyObject != nil {
+		t.Errorf("newBox: expected nil Python space")
+	}
+
+	shape := box.Shape()
+	if len(shape) != 2 || shape[0] != 2 || shape[1] != 2 {
+		t.Errorf("shape: want([2 2]) have(%v)", shape)
+	}
+
+	// Ensure the bounds do not alias the arguments
+	low[0] = 10.0
+	if box.Low()[0].AtVec(0) != -1.0 {
+		t.Errorf("low: bounds alias the constructor arguments")
+	}
+
+	if !box.Contains([]float64{0.0, 1.0, -1.5, -100.0}) {
+		t.Errorf("contains: expected point to be in space")
+	}
+	if box.Contains([]float64{0.0, 6.0, -1.5, 0.0}) {
+		t.Errorf("contains: expected point to not be in space")
+	}
+
+	// Illegal boxes
+	if _, err := gogym.NewBox([]float64{0.0}, []float64{1.0, 2.0},
+		nil); err == nil {
+		t.Errorf("newBox: expected error for mismatched bounds")
+	}
+	if _, err := gogym.NewBox([]float64{0.0, 0.0}, []float64{1.0, 2.0},
+		[]int{3}); err == nil {
+		t.Errorf("newBox: expected error for mismatched shape")
+	}
+	if _, err := gogym.NewBox([]float64{2.0}, []float64{1.0},
+		nil); err == nil {
+		t.Errorf("newBox: expected error for low > high")
+	}
+}
+
+func TestNewDiscrete(t *testing.T) {
+	discrete, err := gogym.NewDiscrete(4)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+
+	if discrete.N() != 4 {
+		t.Errorf("n: want(4) have(%v)", discrete.N())
+	}
+
+	for i := 0; i < 100; i++ {
+		sample := discrete.Sample()
+		if !discrete.Contains(sample[0]) {
+			t.Errorf("sample: sample %v not in space", sample[0])
+		}
+	}
+
+	if _, err := gogym.NewDiscrete(0); err == nil {
+		t.Errorf("newDiscrete: expected error for n == 0")
+	}
+}
+
+func TestNewDictTuple(t *testing.T) {
+	box, err := gogym.NewBox([]float64{0.0, 0.0}, []float64{1.0, 1.0}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	discrete, err := gogym.NewDiscrete(3)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+
+	tuple, err := gogym.NewTuple(box, discrete)
+	if err != nil {
+		t.Fatalf("newTuple: %v", err)
+	}
+	if tuple.Len() != 2 || tuple.At(1) != discrete {
+		t.Errorf("newTuple: subspaces not stored in order")
+	}
+
+	dict, err := gogym.NewDict([]string{"position", "tuple"},
+		[]gogym.Space{box, tuple})
+	if err != nil {
+		t.Fatalf("newDict: %v", err)
+	}
+	space, err := dict.At("tuple")
+	if err != nil {
+		t.Errorf("at: %v", err)
+	} else if space != tuple {
+		t.Errorf("at: wrong space at key tuple")
+	}
+
+	if len(dict.Sample()) != 3 {
+		t.Errorf("sample: want(3) vectors have(%v)", len(dict.Sample()))
+	}
+
+	if _, err := gogym.NewDict([]string{"a", "a"},
+		[]gogym.Space{box, discrete}); err == nil {
+		t.Errorf("newDict: expected error for duplicate keys")
+	}
+	if _, err := gogym.NewTuple(box, nil); err == nil {
+		t.Errorf("newTuple: expected error for nil space")
+	}
+}
+
+func TestToPython(t *testing.T) {
+	box, err := gogym.NewBox(
+		[]float64{-1.0, math.Inf(-1), 0.0, -0.5},
+		[]float64{1.0, 2.0, math.Inf(1), 0.5},
+		[]int{2, 2},
+	)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	discrete, err := gogym.NewDiscrete(5)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	tuple, err := gogym.NewTuple(discrete, box)
+	if err != nil {
+		t.Fatalf("newTuple: %v", err)
+	}
+	dict, err := gogym.NewDict([]string{"box", "discrete", "tuple"},
+		[]gogym.Space{box, discrete, tuple})
+	if err != nil {
+		t.Fatalf("newDict: %v", err)
+	}
+
+	for _, space := range []gogym.Space{box, discrete, tuple, dict} {
+		pySpace, err := gogym.ToPython(space)
+		if err != nil {
+			t.Errorf("toPython: %v", err)
+			continue
+		}
+
+		goSpace, err := gogym.FromPythonSpace(pySpace)
+		if err != nil {
+			t.Errorf("fromPythonSpace: %v", err)
+			continue
+		}
+
+		if fmt.Sprintf("%T", goSpace) != fmt.Sprintf("%T", space) {
+			t.Errorf("fromPythonSpace: want(%T) have(%T)", space, goSpace)
+		}
+		if !equalVecs(space.Low(), goSpace.Low()) {
+			t.Errorf("low: want(%v) have(%v)", space.Low(), goSpace.Low())
+		}
+		if !equalVecs(space.High(), goSpace.High()) {
+			t.Errorf("high: want(%v) have(%v)", space.High(), goSpace.High())
+		}
+		pySpace.DecRef()
+	}
+}
+
+// equalVecs returns whether two slices of vectors are equal
+func equalVecs(a, b []*mat.VecDense) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !mat.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSeed(t *testing.T) {
+	// newSpace returns a new composite space with identical siblings
+	newSpace := func() gogym.Space {
+		box1, err := gogym.NewBox([]float64{-1.0, -1.0}, []float64{1.0, 1.0},
+			nil)
+		if err != nil {
+			t.Fatalf("newBox: %v", err)
+		}
+		box2, err := gogym.NewBox([]float64{-1.0, -1.0}, []float64{1.0, 1.0},
+			nil)
+		if err != nil {
+			t.Fatalf("newBox: %v", err)
+		}
+		discrete, err := gogym.NewDiscrete(10)
+		if err != nil {
+			t.Fatalf("newDiscrete: %v", err)
+		}
+		tuple, err := gogym.NewTuple(box2, discrete)
+		if err != nil {
+			t.Fatalf("newTuple: %v", err)
+		}
+		dict, err := gogym.NewDict([]string{"a", "b"},
+			[]gogym.Space{box1, tuple})
+		if err != nil {
+			t.Fatalf("newDict: %v", err)
+		}
+		return dict
+	}
+
+	// samples returns n samples from space
+	samples := func(space gogym.Space, n int) [][]*mat.VecDense {
+		s := make([][]*mat.VecDense, n)
+		for i := range s {
+			s[i] = space.Sample()
+		}
+		return s
+	}
+
+	// Spaces seeded with the same seed should produce the same samples
+	space1 := newSpace()
+	space1.Seed(42)
+	space2 := newSpace()
+	space2.Seed(42)
+	samples1, samples2 := samples(space1, 20), samples(space2, 20)
+	for i := range samples1 {
+		if !equalVecs(samples1[i], samples2[i]) {
+			t.Errorf("seed: samples differ at index %v: %v != %v", i,
+				samples1[i], samples2[i])
+		}
+	}
+
+	// Sibling sub-spaces should not produce the same samples
+	for i := range samples1 {
+		if mat.Equal(samples1[i][0], samples1[i][1]) {
+			t.Errorf("seed: identical siblings produced correlated samples")
+			break
+		}
+	}
+
+	// Spaces constructed after the package-level seed is set should
+	// produce the same samples without being seeded explicitly
+	gogym.SetSeed(7)
+	samples1 = samples(newSpace(), 20)
+	gogym.SetSeed(7)
+	samples2 = samples(newSpace(), 20)
+	for i := range samples1 {
+		if !equalVecs(samples1[i], samples2[i]) {
+			t.Errorf("setSeed: samples differ at index %v: %v != %v", i,
+				samples1[i], samples2[i])
+		}
+	}
+
+	// Seeds drawn by other packages should also be reproducible
+	gogym.SetSeed(7)
+	seed1 := gogym.NewSeed()
+	gogym.SetSeed(7)
+	if seed2 := gogym.NewSeed(); seed1 != seed2 {
+		t.Errorf("newSeed: seeds differ after setSeed: %v != %v", seed1,
+			seed2)
+	}
+}
+
+func TestBoxSample(t *testing.T) {
+	inf := math.Inf(1)
+	box, err := gogym.NewBox(
+		[]float64{-inf, -inf, 2.0, -1.0},
+		[]float64{inf, 3.0, inf, 1.0},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	box.Seed(1)
+
+	var mean float64
+	n := 1000
+	for i := 0; i < n; i++ {
+		sample := box.Sample()[0]
+		for j := 0; j < sample.Len(); j++ {
+			if math.IsInf(sample.AtVec(j), 0) || math.IsNaN(sample.AtVec(j)) {
+				t.Fatalf("sample: non-finite sample %v", sample)
+			}
+		}
+		if !box.Contains(sample) {
+			t.Fatalf("sample: sample %v not in space", sample)
+		}
+		mean += sample.AtVec(0) / float64(n)
+	}
+
+	// The unbounded dimension is sampled from a standard normal
+	if math.Abs(mean) > 0.2 {
+		t.Errorf("sample: expected mean of unbounded dimension near 0, "+
+			"got %v", mean)
+	}
+}
+
+func TestBoxDType(t *testing.T) {
+	low := make([]float64, 6)
+	high := make([]float64, 6)
+	for i := range high {
+		high[i] = 255.0
+	}
+
+	image, err := gogym.NewTypedBox(low, high, []int{1, 2, 3}, gogym.Uint8)
+	if err != nil {
+		t.Fatalf("newTypedBox: %v", err)
+	}
+	if image.DType() != gogym.Uint8 {
+		t.Errorf("dtype: want(%v) have(%v)", gogym.Uint8, image.DType())
+	}
+
+	if image.Contains([]float64{0.5, 0, 0, 0, 0, 0}) {
+		t.Errorf("contains: non-integer point in uint8 space")
+	}
+	if image.Contains([]float64{256, 0, 0, 0, 0, 0}) {
+		t.Errorf("contains: out of range point in uint8 space")
+	}
+	if !image.Contains([]float64{255, 0, 1, 2, 3, 4}) {
+		t.Errorf("contains: expected point in uint8 space")
+	}
+
+	// Samples of integer spaces should be integers in range, and the
+	// upper bound should be reachable
+	small, err := gogym.NewTypedBox([]float64{-1}, []float64{1}, nil,
+		gogym.Int64)
+	if err != nil {
+		t.Fatalf("newTypedBox: %v", err)
+	}
+	counts := make(map[float64]int)
+	for i := 0; i < 300; i++ {
+		sample := small.Sample()[0]
+		if !small.Contains(sample) {
+			t.Fatalf("sample: sample %v not in space", sample)
+		}
+		counts[sample.AtVec(0)]++
+	}
+	if len(counts) != 3 {
+		t.Errorf("sample: expected samples in {-1, 0, 1}, got %v", counts)
+	}
+
+	// Integer spaces must be bounded
+	if _, err := gogym.NewTypedBox([]float64{0}, []float64{math.Inf(1)}, nil,
+		gogym.Int32); err == nil {
+		t.Errorf("newTypedBox: expected error for unbounded int32 space")
+	}
+
+	// Floating point samples should be representable at the precision
+	// of the data type
+	f32, err := gogym.NewTypedBox([]float64{-1}, []float64{1}, nil,
+		gogym.Float32)
+	if err != nil {
+		t.Fatalf("newTypedBox: %v", err)
+	}
+	sample := f32.Sample()[0].AtVec(0)
+	if float64(float32(sample)) != sample {
+		t.Errorf("sample: %v not representable as float32", sample)
+	}
+}
+
+func TestDictKeyOrder(t *testing.T) {
+	keys := []string{"velocity", "angle", "position"}
+	spaces := make([]gogym.Space, len(keys))
+	for i := range spaces {
+		box, err := gogym.NewBox([]float64{float64(i)},
+			[]float64{float64(i)}, nil)
+		if err != nil {
+			t.Fatalf("newBox: %v", err)
+		}
+		spaces[i] = box
+	}
+
+	dict, err := gogym.NewDict(keys, spaces)
+	if err != nil {
+		t.Fatalf("newDict: %v", err)
+	}
+
+	want := []string{"angle", "position", "velocity"}
+	for i, key := range dict.Keys() {
+		if key != want[i] {
+			t.Fatalf("keys: want(%v) have(%v)", want, dict.Keys())
+		}
+	}
+
+	// The bounds and samples of each sub-space should follow the
+	// sorted keys
+	wantBounds := []float64{1, 2, 0}
+	for i := range wantBounds {
+		if dict.Low()[i].AtVec(0) != wantBounds[i] {
+			t.Errorf("low: want(%v) at index %v have(%v)", wantBounds[i], i,
+				dict.Low()[i].AtVec(0))
+		}
+		if dict.Sample()[i].AtVec(0) != wantBounds[i] {
+			t.Errorf("sample: want(%v) at index %v have(%v)", wantBounds[i],
+				i, dict.Sample()[i].AtVec(0))
+		}
+	}
+
+	// Flattening should always use the sorted keys, regardless of the
+	// iteration order of the input map
+	point := map[string]interface{}{
+		"velocity": []float64{0},
+		"angle":    []float64{1},
+		"position": []float64{2},
+	}
+	for i := 0; i < 20; i++ {
+		flat, err := gogym.Flatten(dict, point)
+		if err != nil {
+			t.Fatalf("flatten: %v", err)
+		}
+		if flat[0] != 1 || flat[1] != 2 || flat[2] != 0 {
+			t.Fatalf("flatten: want([1 2 0]) have(%v)", flat)
+		}
+	}
+}
+
+func TestDiscreteSampleMasked(t *testing.T) {
+	discrete, err := gogym.NewDiscrete(5)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+
+	mask := []bool{false, true, false, true, false}
+	counts := make([]int, 5)
+	for i := 0; i < 200; i++ {
+		sample, err := discrete.SampleMasked(mask)
+		if err != nil {
+			t.Fatalf("sampleMasked: %v", err)
+		}
+		counts[sample]++
+	}
+	for i := range mask {
+		if mask[i] && counts[i] == 0 {
+			t.Errorf("sampleMasked: legal action %v never sampled", i)
+		} else if !mask[i] && counts[i] != 0 {
+			t.Errorf("sampleMasked: illegal action %v sampled", i)
+		}
+	}
+
+	if _, err := discrete.SampleMasked(make([]bool, 5)); err == nil {
+		t.Errorf("sampleMasked: expected error for all-illegal mask")
+	}
+	if _, err := discrete.SampleWeighted([]float64{1, 2}); err == nil {
+		t.Errorf("sampleWeighted: expected error for wrong length")
+	}
+}
+
+func TestDiscreteStart(t *testing.T) {
+	// Create the space in Python as gym.spaces.Discrete(3, start=-1)
+	spaces := python.PyImport_ImportModule("gym.spaces")
+	defer spaces.DecRef()
+	if spaces == nil {
+		t.Fatalf("could not import gym.spaces")
+	}
+	discreteSpace := spaces.GetAttrString("Discrete")
+	defer discreteSpace.DecRef()
+
+	args := python.PyTuple_New(1)
+	defer args.DecRef()
+	python.PyTuple_SetItem(args, 0, python.PyLong_FromGoInt(3))
+	kwargs := python.PyDict_New()
+	defer kwargs.DecRef()
+	start := python.PyLong_FromGoInt(-1)
+	defer start.DecRef()
+	python.PyDict_SetItemString(kwargs, "start", start)
+
+	pySpace := discreteSpace.Call(args, kwargs)
+	defer pySpace.DecRef()
+	if pySpace == nil {
+		t.Fatalf("could not create gym.spaces.Discrete with start")
+	}
+
+	space, err := gogym.FromPythonSpace(pySpace)
+	if err != nil {
+		t.Fatalf("fromPythonSpace: %v", err)
+	}
+	discrete := space.(*gogym.DiscreteSpace)
+
+	if discrete.Start() != -1 || discrete.N() != 3 {
+		t.Errorf("start, n: want(-1, 3) have(%v, %v)", discrete.Start(),
+			discrete.N())
+	}
+	if low := discrete.Low()[0].AtVec(0); low != -1 {
+		t.Errorf("low: want(-1) have(%v)", low)
+	}
+	if high := discrete.High()[0].AtVec(0); high != 1 {
+		t.Errorf("high: want(1) have(%v)", high)
+	}
+	for _, x := range []int{-1, 0, 1} {
+		if !discrete.Contains(x) {
+			t.Errorf("contains: %v should be in space", x)
+		}
+	}
+	for _, x := range []int{-2, 2} {
+		if discrete.Contains(x) {
+			t.Errorf("contains: %v should not be in space", x)
+		}
+	}
+
+	counts := make(map[int]int)
+	for i := 0; i < 200; i++ {
+		sample := discrete.SamplePoint().(int)
+		if !discrete.Contains(sample) {
+			t.Fatalf("samplePoint: %v not in space", sample)
+		}
+		counts[sample]++
+	}
+	if len(counts) != 3 {
+		t.Errorf("samplePoint: want 3 distinct samples, have %v", counts)
+	}
+
+	// Only action 1, the last action of the space, is legal
+	sample, err := discrete.SampleMasked([]bool{false, false, true})
+	if err != nil {
+		t.Fatalf("sampleMasked: %v", err)
+	}
+	if sample != 1 {
+		t.Errorf("sampleMasked: want(1) have(%v)", sample)
+	}
+
+	flat, err := gogym.Flatten(discrete, 0)
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	if !floats.Equal(flat, []float64{0, 1, 0}) {
+		t.Errorf("flatten: want([0 1 0]) have(%v)", flat)
+	}
+	if _, err = gogym.Flatten(discrete, 2); err == nil {
+		t.Errorf("flatten: expected error for point not in space")
+	}
+	point, err := gogym.Unflatten(discrete, flat)
+	if err != nil {
+		t.Fatalf("unflatten: %v", err)
+	}
+	if point != 0 {
+		t.Errorf("unflatten: want(0) have(%v)", point)
+	}
+
+	// The start should survive a round trip to Python
+	pySpace2, err := gogym.ToPython(discrete)
+	if err != nil {
+		t.Fatalf("toPython: %v", err)
+	}
+	defer pySpace2.DecRef()
+	space, err = gogym.FromPythonSpace(pySpace2)
+	if err != nil {
+		t.Fatalf("fromPythonSpace: %v", err)
+	}
+	if start := space.(*gogym.DiscreteSpace).Start(); start != -1 {
+		t.Errorf("toPython: want start(-1) have(%v)", start)
+	}
+}
+
+func TestBoxStack(t *testing.T) {
+	box, err := gogym.NewTypedBox([]float64{0, -1}, []float64{1, 2}, nil,
+		gogym.Float32)
+	if err != nil {
+		t.Fatalf("newTypedBox: %v", err)
+	}
+
+	stacked, err := box.Stack(3)
+	if err != nil {
+		t.Fatalf("stack: %v", err)
+	}
+	if shape := stacked.Shape(); len(shape) != 2 || shape[0] != 3 ||
+		shape[1] != 2 {
+		t.Errorf("stack: want shape([3 2]) have(%v)", shape)
+	}
+	if stacked.DType() != gogym.Float32 {
+		t.Errorf("stack: want dtype(%v) have(%v)", gogym.Float32,
+			stacked.DType())
+	}
+
+	wantLow := []float64{0, -1, 0, -1, 0, -1}
+	if low := stacked.Low()[0].RawVector().Data; !floats.Equal(low,
+		wantLow) {
+		t.Errorf("stack: want low(%v) have(%v)", wantLow, low)
+	}
+	wantHigh := []float64{1, 2, 1, 2, 1, 2}
+	if high := stacked.High()[0].RawVector().Data; !floats.Equal(high,
+		wantHigh) {
+		t.Errorf("stack: want high(%v) have(%v)", wantHigh, high)
+	}
+
+	if _, err = box.Stack(0); err == nil {
+		t.Errorf("stack: expected error for k = 0")
+	}
+}
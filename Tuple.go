@@ -21,6 +21,9 @@ type TupleSpace struct {
 // NewTupleSpace takes a Python TupleSpace and converts it to its Go
 // equivalent
 func NewTupleSpace(space *python.PyObject) (Space, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("newTupleSpace: %v", err)
+	}
 	if !(space.Type() == tupleSpace) {
 		return nil, fmt.Errorf("newTupleSpace: space is not a tuple space")
 	}
@@ -45,13 +48,35 @@ func NewTupleSpace(space *python.PyObject) (Space, error) {
 		spaces[i] = value
 	}
 
-	return &TupleSpace{spaces}, nil
+	tuple, err := NewTuple(spaces...)
+	if err != nil {
+		return nil, fmt.Errorf("newTupleSpace: %v", err)
+	}
+
+	return tuple, nil
 }
 
-// Seed seeds the RNG for all sub-spaces recursively
+// NewTuple returns a new TupleSpace which is the product of the
+// argument spaces, without requiring a Python gym.spaces.Tuple.
+func NewTuple(spaces ...Space) (*TupleSpace, error) {
+	for i, space := range spaces {
+		if space == nil {
+			return nil, fmt.Errorf("newTuple: nil space at index %v", i)
+		}
+	}
+
+	values := make([]Space, len(spaces))
+	copy(values, spaces)
+
+	return &TupleSpace{values}, nil
+}
+
+// Seed seeds the RNG for all sub-spaces recursively. Each sub-space is
+// seeded with a different seed derived from the argument seed, so
+// that sub-spaces do not produce correlated samples.
 func (t *TupleSpace) Seed(seed uint64) {
-	for _, space := range t.spaces {
-		space.Seed(seed)
+	for i, subseed := range subseeds(seed, len(t.spaces)) {
+		t.spaces[i].Seed(subseed)
 	}
 }
 
@@ -117,6 +142,22 @@ func (t *TupleSpace) Sample() []*mat.VecDense {
 	return sample
 }
 
+// SamplePoint takes a sample from within the space bounds and returns
+// it as a []interface{}, which holds a point sampled from each
+// sub-space at the sub-space's index. SamplePoint panics if a point
+// cannot be sampled from a sub-space (see the SamplePoint function).
+func (t *TupleSpace) SamplePoint() interface{} {
+	sample := make([]interface{}, t.Len())
+	for i, space := range t.spaces {
+		point, err := SamplePoint(space)
+		if err != nil {
+			panic(fmt.Sprintf("samplePoint: index %v: %v", i, err))
+		}
+		sample[i] = point
+	}
+	return sample
+}
+
 func (t *TupleSpace) Len() int {
 	return len(t.spaces)
 }
@@ -125,3 +166,44 @@ func (t *TupleSpace) Len() int {
 func (t *TupleSpace) At(i int) Space {
 	return t.spaces[i]
 }
+
+// toPython converts the TupleSpace to a Python gym.spaces.Tuple.
+// Creates a new python.PyObject reference.
+func (t *TupleSpace) toPython() (*python.PyObject, error) {
+	if err := importGym(); err != nil {
+		return nil, fmt.Errorf("toPython: %v", err)
+	}
+	pyTuple := python.PyTuple_New(t.Len())
+	defer pyTuple.DecRef()
+
+	for i, space := range t.spaces {
+		value, err := ToPython(space)
+		if err != nil {
+			return nil, fmt.Errorf("toPython: could not convert space at "+
+				"index %v: %v", i, err)
+		}
+
+		// PyTuple_SetItem steals the reference to value
+		if python.PyTuple_SetItem(pyTuple, i, value) != 0 {
+			if python.PyErr_Occurred() != nil {
+				python.PyErr_Print()
+			}
+			return nil, fmt.Errorf("toPython: could not set index %v", i)
+		}
+	}
+
+	args := python.PyTuple_New(1)
+	defer args.DecRef()
+	pyTuple.IncRef()
+	python.PyTuple_SetItem(args, 0, pyTuple)
+
+	space := tupleSpace.CallObject(args)
+	if space == nil {
+		if python.PyErr_Occurred() != nil {
+			python.PyErr_Print()
+		}
+		return nil, fmt.Errorf("toPython: could not construct " +
+			"gym.spaces.Tuple")
+	}
+	return space, nil
+}
@@ -0,0 +1,201 @@
+package gogym
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// FlatDim returns the number of dimensions of a flattened point from
+// space, that is the length of the []float64 returned by Flatten. It
+// is equivalent to gym.spaces.utils.flatdim.
+func FlatDim(space Space) (int, error) {
+	switch s := space.(type) {
+	case *BoxSpace:
+		return s.low.Len(), nil
+
+	case *DiscreteSpace:
+		return s.n, nil
+
+	case *TupleSpace:
+		dim := 0
+		for i := 0; i < s.Len(); i++ {
+			subDim, err := FlatDim(s.At(i))
+			if err != nil {
+				return 0, fmt.Errorf("flatDim: %v", err)
+			}
+			dim += subDim
+		}
+		return dim, nil
+
+	case *DictSpace:
+		dim := 0
+		for _, value := range s.values {
+			subDim, err := FlatDim(value)
+			if err != nil {
+				return 0, fmt.Errorf("flatDim: %v", err)
+			}
+			dim += subDim
+		}
+		return dim, nil
+	}
+
+	return 0, fmt.Errorf("flatDim: space type %T not yet implemented",
+		space)
+}
+
+// FlattenSpace returns a BoxSpace which contains all flattened points
+// from space. That is, for each x in space, Flatten(space, x) is in
+// FlattenSpace(space). It is equivalent to
+// gym.spaces.utils.flatten_space.
+//
+// The data type of the returned BoxSpace is the data type of space if
+// space is a BoxSpace, Int64 if space is a DiscreteSpace, and the
+// common data type of all sub-spaces (or Float64 if the sub-spaces
+// have different data types) if space is a composite space.
+//
+// Since a BoxSpace must have at least one dimension, FlattenSpace
+// returns an error if space is a TupleSpace or DictSpace containing no
+// BoxSpaces or DiscreteSpaces, for example an empty TupleSpace. Points
+// from such spaces can still be flattened with Flatten, which returns
+// an empty slice.
+func FlattenSpace(space Space) (*BoxSpace, error) {
+	low, high, dtype, err := flattenBounds(space)
+	if err != nil {
+		return nil, fmt.Errorf("flattenSpace: %v", err)
+	}
+	if len(low) == 0 {
+		return nil, fmt.Errorf("flattenSpace: cannot flatten empty %T, "+
+			"which has no dimensions", space)
+	}
+
+	box, err := NewTypedBox(low, high, nil, dtype)
+	if err != nil {
+		return nil, fmt.Errorf("flattenSpace: %v", err)
+	}
+	return box, nil
+}
+
+// flattenBounds returns the bounds and data type of the flattened
+// version of space
+func flattenBounds(space Space) ([]float64, []float64, DType, error) {
+	switch s := space.(type) {
+	case *BoxSpace:
+		low := make([]float64, s.low.Len())
+		copy(low, s.low.RawVector().Data)
+		high := make([]float64, s.high.Len())
+		copy(high, s.high.RawVector().Data)
+		return low, high, s.dtype, nil
+
+	case *DiscreteSpace:
+		low := make([]float64, s.n)
+		high := make([]float64, s.n)
+		for i := range high {
+			high[i] = 1.0
+		}
+		return low, high, Int64, nil
+
+	case *TupleSpace:
+		return concatBounds(s.spaces)
+
+	case *DictSpace:
+		return concatBounds(s.values)
+	}
+
+	return nil, nil, "", fmt.Errorf("space type %T not yet implemented",
+		space)
+}
+
+// concatBounds concatenates the flattened bounds of each space in
+// spaces
+func concatBounds(spaces []Space) ([]float64, []float64, DType, error) {
+	var low, high []float64
+	var dtype DType
+	for i, space := range spaces {
+		subLow, subHigh, subDType, err := flattenBounds(space)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		low = append(low, subLow...)
+		high = append(high, subHigh...)
+
+		if i == 0 {
+			dtype = subDType
+		} else if dtype != subDType {
+			dtype = Float64
+		}
+	}
+
+	if dtype == "" {
+		dtype = Float64
+	}
+	return low, high, dtype, nil
+}
+
+// Unflatten is the inverse of Flatten. It accepts a space and a
+// flattened point from that space and returns the point in its
+// original structure. It is equivalent to gym.spaces.utils.unflatten.
+// For each space, the returned point has the following concrete type:
+//
+//	Space			Type
+//	BoxSpace		*mat.VecDense
+//	DiscreteSpace	int
+//	TupleSpace		[]interface{}
+//	DictSpace		map[string]interface{}
+func Unflatten(space Space, x []float64) (interface{}, error) {
+	dim, err := FlatDim(space)
+	if err != nil {
+		return nil, fmt.Errorf("unflatten: %v", err)
+	}
+	if len(x) != dim {
+		return nil, fmt.Errorf("unflatten: input should have same length "+
+			"as flattened space \n\twant(%v) \n\thave(%v)", dim, len(x))
+	}
+
+	switch s := space.(type) {
+	case *BoxSpace:
+		data := make([]float64, len(x))
+		copy(data, x)
+		return mat.NewVecDense(len(data), data), nil
+
+	case *DiscreteSpace:
+		for i := range x {
+			if x[i] != 0 {
+				return i + s.start, nil
+			}
+		}
+		return nil, fmt.Errorf("unflatten: one-hot vector %v has no "+
+			"non-zero element", x)
+
+	case *TupleSpace:
+		tuple := make([]interface{}, s.Len())
+		start := 0
+		for i := range tuple {
+			subDim, _ := FlatDim(s.At(i))
+			tuple[i], err = Unflatten(s.At(i), x[start:start+subDim])
+			if err != nil {
+				return nil, fmt.Errorf("unflatten: could not unflatten "+
+					"tuple element at index %v: %v", i, err)
+			}
+			start += subDim
+		}
+		return tuple, nil
+
+	case *DictSpace:
+		dict := make(map[string]interface{}, s.Len())
+		start := 0
+		for i, key := range s.keys {
+			subDim, _ := FlatDim(s.values[i])
+			dict[key], err = Unflatten(s.values[i], x[start:start+subDim])
+			if err != nil {
+				return nil, fmt.Errorf("unflatten: could not unflatten "+
+					"value at key %v: %v", key, err)
+			}
+			start += subDim
+		}
+		return dict, nil
+	}
+
+	return nil, fmt.Errorf("unflatten: space type %T not yet implemented",
+		space)
+}
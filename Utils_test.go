@@ -0,0 +1,154 @@
+package gogym_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// nestedSpace returns a Dict space containing a nested Tuple and Dict
+func nestedSpace(t *testing.T) gogym.Space {
+	box, err := gogym.NewBox([]float64{-1, -2, -3, -4},
+		[]float64{1, 2, 3, 4}, []int{2, 2})
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	discrete, err := gogym.NewDiscrete(3)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	scalar, err := gogym.NewTypedBox([]float64{0}, []float64{10}, nil,
+		gogym.Float32)
+	if err != nil {
+		t.Fatalf("newTypedBox: %v", err)
+	}
+
+	inner, err := gogym.NewDict([]string{"scalar", "action"},
+		[]gogym.Space{scalar, discrete})
+	if err != nil {
+		t.Fatalf("newDict: %v", err)
+	}
+	tuple, err := gogym.NewTuple(discrete, inner)
+	if err != nil {
+		t.Fatalf("newTuple: %v", err)
+	}
+	outer, err := gogym.NewDict([]string{"box", "tuple"},
+		[]gogym.Space{box, tuple})
+	if err != nil {
+		t.Fatalf("newDict: %v", err)
+	}
+	return outer
+}
+
+func TestFlattenRoundTrip(t *testing.T) {
+	space := nestedSpace(t)
+	point := map[string]interface{}{
+		"box": mat.NewVecDense(4, []float64{0.5, -1.5, 2.5, -3.5}),
+		"tuple": []interface{}{
+			2,
+			map[string]interface{}{
+				"scalar": []float64{7},
+				"action": 1,
+			},
+		},
+	}
+
+	dim, err := gogym.FlatDim(space)
+	if err != nil {
+		t.Fatalf("flatDim: %v", err)
+	}
+	if dim != 4+3+1+3 {
+		t.Errorf("flatDim: want(%v) have(%v)", 4+3+1+3, dim)
+	}
+
+	flat, err := gogym.Flatten(space, point)
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	if len(flat) != dim {
+		t.Errorf("flatten: want(%v) elements have(%v)", dim, len(flat))
+	}
+
+	flatSpace, err := gogym.FlattenSpace(space)
+	if err != nil {
+		t.Fatalf("flattenSpace: %v", err)
+	}
+	if !flatSpace.Contains(flat) {
+		t.Errorf("flattenSpace: flattened point %v not in flattened space",
+			flat)
+	}
+	if flatSpace.DType() != gogym.Float64 {
+		t.Errorf("flattenSpace: want(%v) have(%v)", gogym.Float64,
+			flatSpace.DType())
+	}
+
+	unflat, err := gogym.Unflatten(space, flat)
+	if err != nil {
+		t.Fatalf("unflatten: %v", err)
+	}
+	tuple := unflat.(map[string]interface{})["tuple"].([]interface{})
+	if tuple[0] != 2 {
+		t.Errorf("unflatten: want(2) have(%v)", tuple[0])
+	}
+	if action := tuple[1].(map[string]interface{})["action"]; action != 1 {
+		t.Errorf("unflatten: want(1) have(%v)", action)
+	}
+
+	reflat, err := gogym.Flatten(space, unflat)
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	if !floats.Equal(flat, reflat) {
+		t.Errorf("unflatten: round trip failed \n\twant(%v) \n\thave(%v)",
+			flat, reflat)
+	}
+
+	// Illegal flattened points
+	if _, err := gogym.Unflatten(space, flat[1:]); err == nil {
+		t.Errorf("unflatten: expected error for wrong length")
+	}
+}
+
+func TestFlattenEmptySpace(t *testing.T) {
+	tuple, err := gogym.NewTuple()
+	if err != nil {
+		t.Fatalf("newTuple: %v", err)
+	}
+	dict, err := gogym.NewDict(nil, nil)
+	if err != nil {
+		t.Fatalf("newDict: %v", err)
+	}
+	nested, err := gogym.NewTuple(tuple, dict)
+	if err != nil {
+		t.Fatalf("newTuple: %v", err)
+	}
+
+	points := []interface{}{
+		[]interface{}{},
+		map[string]interface{}{},
+		[]interface{}{[]interface{}{}, map[string]interface{}{}},
+	}
+	for i, space := range []gogym.Space{tuple, dict, nested} {
+		if _, err := gogym.FlattenSpace(space); err == nil {
+			t.Errorf("flattenSpace: expected error for empty %T", space)
+		}
+
+		dim, err := gogym.FlatDim(space)
+		if err != nil {
+			t.Fatalf("flatDim: %v", err)
+		}
+		if dim != 0 {
+			t.Errorf("flatDim: want(0) have(%v)", dim)
+		}
+
+		flat, err := gogym.Flatten(space, points[i])
+		if err != nil {
+			t.Fatalf("flatten: %v", err)
+		}
+		if len(flat) != 0 {
+			t.Errorf("flatten: want([]) have(%v)", flat)
+		}
+	}
+}
@@ -1,10 +1,11 @@
 module github.com/samuelfneumann/gogym
 
-go 1.16
+go 1.18
 
 require (
 	github.com/DataDog/go-python3 v0.0.0-20210805105248-03d93fb21b67
-	github.com/stretchr/testify v1.7.0 // indirect
 	golang.org/x/exp v0.0.0-20191002040644-a1355ae1e2c3
 	gonum.org/v1/gonum v0.9.3
 )
+
+require github.com/stretchr/testify v1.7.0 // indirect
@@ -0,0 +1,76 @@
+package wrappers
+
+import (
+	"fmt"
+
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ActionDelay wraps a gogym.Environment and delays each action by a
+// fixed number of steps using a queue. Each action given to Step is
+// taken in the wrapped environment delay steps later. At the start of
+// each episode, the queue is filled with an initial action, which is
+// taken for the first delay steps.
+type ActionDelay struct {
+	*ActionWrapper
+	delay   int
+	initial *mat.VecDense
+
+	// queue holds the actions which have not yet been taken, from
+	// oldest to newest
+	queue []*mat.VecDense
+}
+
+// NewActionDelay returns a new ActionDelay which delays the actions
+// taken in env by delay steps, taking action initial for the first
+// delay steps of each episode. Action initial must be in the action
+// space of env.
+func NewActionDelay(env gogym.Environment, delay int,
+	initial *mat.VecDense) (gogym.Environment, error) {
+	if delay <= 0 {
+		return nil, fmt.Errorf("newActionDelay: delay must be positive, "+
+			"got %v", delay)
+	}
+	if initial == nil || !env.ActionSpace().Contains(initial) {
+		return nil, fmt.Errorf("newActionDelay: initial action %v not in "+
+			"action space", initial)
+	}
+
+	d := &ActionDelay{
+		delay:   delay,
+		initial: mat.VecDenseCopyOf(initial),
+	}
+	d.fill()
+
+	var err error
+	d.ActionWrapper, err = NewActionWrapper(env, nil, d.action)
+	if err != nil {
+		return nil, fmt.Errorf("newActionDelay: %v", err)
+	}
+	return d, nil
+}
+
+// action adds a to the queue and returns the oldest action in the
+// queue
+func (d *ActionDelay) action(a *mat.VecDense) (*mat.VecDense, error) {
+	d.queue = append(d.queue, mat.VecDenseCopyOf(a))
+	next := d.queue[0]
+	d.queue = d.queue[1:]
+	return next, nil
+}
+
+// fill fills the queue with the initial action
+func (d *ActionDelay) fill() {
+	d.queue = make([]*mat.VecDense, d.delay)
+	for i := range d.queue {
+		d.queue[i] = d.initial
+	}
+}
+
+// Reset resets the environment and refills the queue with the initial
+// action
+func (d *ActionDelay) Reset() (*mat.VecDense, error) {
+	d.fill()
+	return d.ActionWrapper.Reset()
+}
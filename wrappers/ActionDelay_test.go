@@ -0,0 +1,53 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewActionDelay(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(10)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 5)
+
+	env, err := wrappers.NewActionDelay(goEnv, 2,
+		mat.NewVecDense(1, []float64{9}))
+	if err != nil {
+		t.Fatalf("newActionDelay: %v", err)
+	}
+
+	// Actions are delayed by 2 steps, and the queue is refilled with the
+	// initial action on reset
+	for episode := 0; episode < 2; episode++ {
+		if _, err = env.Reset(); err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+		goEnv.actions = nil
+
+		for i := 0; i < 5; i++ {
+			_, _, _, err = env.Step(mat.NewVecDense(1, []float64{float64(i)}))
+			if err != nil {
+				t.Fatalf("step: %v", err)
+			}
+		}
+
+		want := []float64{9, 9, 0, 1, 2}
+		for i := range want {
+			if goEnv.actions[i].AtVec(0) != want[i] {
+				t.Errorf("step: want action(%v) at step %v have(%v)",
+					want[i], i, goEnv.actions[i].AtVec(0))
+			}
+		}
+	}
+
+	if _, err = wrappers.NewActionDelay(goEnv, 2,
+		mat.NewVecDense(1, []float64{10})); err == nil {
+		t.Errorf("newActionDelay: expected error for initial action not " +
+			"in action space")
+	}
+}
@@ -0,0 +1,118 @@
+package wrappers
+
+import (
+	"fmt"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ActionMask wraps a gogym.Environment with a DiscreteSpace action
+// space and state-dependent legal actions. Before each step, the mask
+// of legal actions is read from the info dictionary of the wrapped
+// environment (see gogym.ActionMask), and illegal actions are either
+// rejected or remapped to legal actions before they reach Python.
+//
+// If the wrapped environment's info dictionary holds no action mask,
+// all actions are legal. In particular, GymEnvs only return an info
+// dictionary from Reset with gym 0.26 and later, so with older versions
+// of gym the first action of each episode is never masked.
+type ActionMask struct {
+	gogym.Environment
+	actionSpace *gogym.DiscreteSpace
+
+	remap bool
+}
+
+// NewActionMask returns a new gogym.Environment that checks the legality
+// of each action taken in env. If remap is true, then each illegal
+// action is replaced by an action sampled uniformly from the legal
+// actions. Otherwise, taking an illegal action results in an error
+// from Step and the action is not taken in env.
+func NewActionMask(env gogym.Environment, remap bool) (gogym.Environment,
+	error) {
+	actionSpace, ok := env.ActionSpace().(*gogym.DiscreteSpace)
+	if !ok {
+		return nil, fmt.Errorf("newActionMask: could not wrap environment "+
+			"with non-DiscreteSpace action space %T", env.ActionSpace())
+	}
+
+	return &ActionMask{
+		Environment: env,
+		actionSpace: actionSpace,
+		remap:       remap,
+	}, nil
+}
+
+// Mask returns the current mask of legal actions. Element i of the
+// mask is true if the i-th action of the action space is legal.
+func (m *ActionMask) Mask() ([]bool, error) {
+	mask, err := gogym.ActionMask(m.Environment)
+	if err != nil {
+		return nil, fmt.Errorf("mask: %v", err)
+	}
+
+	if mask == nil {
+		mask = make([]bool, m.actionSpace.N())
+		for i := range mask {
+			mask[i] = true
+		}
+	}
+	return mask, nil
+}
+
+// Action returns the action which will be taken in the wrapped
+// environment when action a is taken in the ActionMask environment
+func (m *ActionMask) Action(a *mat.VecDense) (*mat.VecDense, error) {
+	if !m.actionSpace.Contains(a) {
+		return nil, fmt.Errorf("action: action %v not in action space", a)
+	}
+
+	mask, err := m.Mask()
+	if err != nil {
+		return nil, fmt.Errorf("action: %v", err)
+	}
+	if len(mask) != m.actionSpace.N() {
+		return nil, fmt.Errorf("action: mask should have same length as "+
+			"action space \n\twant(%v) \n\thave(%v)", m.actionSpace.N(),
+			len(mask))
+	}
+
+	action := int(a.AtVec(0))
+	if mask[action-m.actionSpace.Start()] {
+		return a, nil
+	}
+	if !m.remap {
+		return nil, fmt.Errorf("action: illegal action %v", action)
+	}
+
+	legalAction, err := m.actionSpace.SampleMasked(mask)
+	if err != nil {
+		return nil, fmt.Errorf("action: could not remap illegal action: %v",
+			err)
+	}
+	return mat.NewVecDense(1, []float64{float64(legalAction)}), nil
+}
+
+// Step takes one environmental step given some action a, first
+// rejecting or remapping a if it is illegal
+func (m *ActionMask) Step(a *mat.VecDense) (*mat.VecDense, float64, bool,
+	error) {
+	action, err := m.Action(a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+	return m.Environment.Step(action)
+}
+
+// Env returns nil, since an ActionMask has no Python environment
+func (m *ActionMask) Env() *python.PyObject {
+	return nil
+}
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (m *ActionMask) Info() map[string]interface{} {
+	return gogym.Info(m.Environment)
+}
@@ -0,0 +1,124 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewActionMask(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(4)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+
+	// Only action t % 4 is legal after t steps
+	goEnv := newGoEnvironment(t, actionSpace, 100)
+	goEnv.infoFunc = func(t int) map[string]interface{} {
+		mask := make([]float64, 4)
+		mask[t%4] = 1
+		return map[string]interface{}{gogym.ActionMaskKey: mask}
+	}
+
+	// Rejecting illegal actions
+	env, err := wrappers.NewActionMask(goEnv, false)
+	if err != nil {
+		t.Fatalf("newActionMask: %v", err)
+	}
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	// The mask returned by Reset applies to the first step
+	if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{3})); err == nil {
+		t.Errorf("step: expected error for illegal first action")
+	}
+	if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{0})); err != nil {
+		t.Errorf("step: %v", err)
+	}
+	if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{0})); err == nil {
+		t.Errorf("step: expected error for illegal action")
+	}
+	if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{1})); err != nil {
+		t.Errorf("step: %v", err)
+	}
+	if len(goEnv.actions) != 2 {
+		t.Errorf("step: illegal action reached wrapped environment")
+	}
+
+	// All actions are legal if the info dictionary holds no mask
+	goEnv.infoFunc = func(t int) map[string]interface{} {
+		if t == 0 {
+			return map[string]interface{}{}
+		}
+		mask := make([]float64, 4)
+		mask[t%4] = 1
+		return map[string]interface{}{gogym.ActionMaskKey: mask}
+	}
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{3})); err != nil {
+		t.Errorf("step: %v", err)
+	}
+
+	// Remapping illegal actions
+	env, err = wrappers.NewActionMask(goEnv, true)
+	if err != nil {
+		t.Fatalf("newActionMask: %v", err)
+	}
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	env.Step(mat.NewVecDense(1, []float64{0}))
+	for i := 0; i < 10; i++ {
+		mask, err := env.(*wrappers.ActionMask).Mask()
+		if err != nil {
+			t.Fatalf("mask: %v", err)
+		}
+		if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{0})); err != nil {
+			t.Fatalf("step: %v", err)
+		}
+
+		taken := int(goEnv.actions[len(goEnv.actions)-1].AtVec(0))
+		if !mask[taken] {
+			t.Errorf("step: illegal action %v taken with mask %v", taken,
+				mask)
+		}
+	}
+
+	// The info dictionary, and so the mask, is forwarded by ActionMask
+	inner, err := wrappers.NewActionMask(goEnv, false)
+	if err != nil {
+		t.Fatalf("newActionMask: %v", err)
+	}
+	env, err = wrappers.NewActionMask(inner, false)
+	if err != nil {
+		t.Fatalf("newActionMask: %v", err)
+	}
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{0})); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	mask, err := env.(*wrappers.ActionMask).Mask()
+	if err != nil {
+		t.Fatalf("mask: %v", err)
+	}
+	if mask[0] || !mask[1] {
+		t.Errorf("mask: expected mask of wrapped environment, got %v", mask)
+	}
+
+	// Only DiscreteSpace action spaces can be masked
+	box, err := gogym.NewBox([]float64{-1}, []float64{1}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	if _, err = wrappers.NewActionMask(newGoEnvironment(t, box, 10),
+		false); err == nil {
+		t.Errorf("newActionMask: expected error for BoxSpace actions")
+	}
+}
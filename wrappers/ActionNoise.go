@@ -0,0 +1,110 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gogym"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ActionNoise wraps a gogym.Environment with a BoxSpace action space
+// and adds random noise to each action before it is taken in the
+// wrapped environment. Noisy actions are clipped to the bounds of the
+// action space.
+type ActionNoise struct {
+	*ActionWrapper
+	actionSpace *gogym.BoxSpace
+	rng         *rand.Rand
+
+	// noise returns a single sample of noise
+	noise func() float64
+}
+
+// NewGaussianActionNoise returns a new ActionNoise which adds Gaussian
+// noise with mean 0 and standard deviation stddev to each dimension of
+// the actions taken in env
+func NewGaussianActionNoise(env gogym.Environment,
+	stddev float64) (gogym.Environment, error) {
+	if stddev < 0 {
+		return nil, fmt.Errorf("newGaussianActionNoise: stddev must be "+
+			"non-negative, got %v", stddev)
+	}
+
+	n, err := newActionNoise(env)
+	if err != nil {
+		return nil, fmt.Errorf("newGaussianActionNoise: %v", err)
+	}
+	n.noise = func() float64 {
+		return n.rng.NormFloat64() * stddev
+	}
+	return n, nil
+}
+
+// NewUniformActionNoise returns a new ActionNoise which adds noise
+// sampled uniformly from [-width, width] to each dimension of the
+// actions taken in env
+func NewUniformActionNoise(env gogym.Environment,
+	width float64) (gogym.Environment, error) {
+	if width < 0 {
+		return nil, fmt.Errorf("newUniformActionNoise: width must be "+
+			"non-negative, got %v", width)
+	}
+
+	n, err := newActionNoise(env)
+	if err != nil {
+		return nil, fmt.Errorf("newUniformActionNoise: %v", err)
+	}
+	n.noise = func() float64 {
+		return (2*n.rng.Float64() - 1) * width
+	}
+	return n, nil
+}
+
+// newActionNoise returns a new ActionNoise without a noise function
+func newActionNoise(env gogym.Environment) (*ActionNoise, error) {
+	box, ok := env.ActionSpace().(*gogym.BoxSpace)
+	if !ok {
+		return nil, fmt.Errorf("action space should be a BoxSpace but "+
+			"got %T", env.ActionSpace())
+	}
+
+	src := rand.NewSource(gogym.NewSeed())
+	n := &ActionNoise{
+		actionSpace: box,
+		rng:         rand.New(src),
+	}
+
+	var err error
+	n.ActionWrapper, err = NewActionWrapper(env, nil, n.action)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// action returns a with noise added, clipped to the bounds of the
+// action space
+func (n *ActionNoise) action(a *mat.VecDense) (*mat.VecDense, error) {
+	low := n.actionSpace.Low()[0]
+	high := n.actionSpace.High()[0]
+	if a.Len() != low.Len() {
+		return nil, fmt.Errorf("action: action should have length %v but "+
+			"got %v", low.Len(), a.Len())
+	}
+
+	noisy := mat.NewVecDense(a.Len(), nil)
+	for i := 0; i < a.Len(); i++ {
+		x := a.AtVec(i) + n.noise()
+		noisy.SetVec(i, math.Max(low.AtVec(i), math.Min(high.AtVec(i), x)))
+	}
+	return noisy, nil
+}
+
+// Seed seeds the wrapped environment and the random number generator
+// of the noise
+func (n *ActionNoise) Seed(seed int) ([]int, error) {
+	n.rng.Seed(uint64(seed))
+	return n.Environment.Seed(seed)
+}
@@ -0,0 +1,143 @@
+package wrappers_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewActionNoise(t *testing.T) {
+	box, err := gogym.NewBox([]float64{-1, -1}, []float64{1, 1}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+
+	constructors := map[string]func(gogym.Environment) (gogym.Environment,
+		error){
+		"gaussian": func(env gogym.Environment) (gogym.Environment, error) {
+			return wrappers.NewGaussianActionNoise(env, 0.5)
+		},
+		"uniform": func(env gogym.Environment) (gogym.Environment, error) {
+			return wrappers.NewUniformActionNoise(env, 0.5)
+		},
+	}
+
+	for name, newActionNoise := range constructors {
+		// takenActions returns the first element of each action taken in
+		// the wrapped environment when taking action [0.9, 0]. The
+		// wrapper is seeded with seed if it is non-negative.
+		takenActions := func(seed int) []float64 {
+			goEnv := newGoEnvironment(t, box, 1000)
+			env, err := newActionNoise(goEnv)
+			if err != nil {
+				t.Fatalf("%v: %v", name, err)
+			}
+			if seed >= 0 {
+				env.Seed(seed)
+			}
+			if _, err = env.Reset(); err != nil {
+				t.Fatalf("reset: %v", err)
+			}
+
+			for i := 0; i < 500; i++ {
+				_, _, _, err = env.Step(mat.NewVecDense(2,
+					[]float64{0.9, 0}))
+				if err != nil {
+					t.Fatalf("step: %v", err)
+				}
+			}
+
+			taken := make([]float64, len(goEnv.actions))
+			for i, action := range goEnv.actions {
+				if !box.Contains(action) {
+					t.Errorf("%v: noisy action %v not clipped to action "+
+						"space", name, action.RawVector().Data)
+				}
+				taken[i] = action.AtVec(0)
+			}
+			return taken
+		}
+
+		taken := takenActions(1)
+		clipped := 0
+		for _, action := range taken {
+			if action == 1 {
+				clipped++
+			}
+			if math.Abs(action-0.9) > 3 {
+				t.Errorf("%v: noise too large for action %v", name, action)
+			}
+		}
+		if clipped == 0 || clipped == len(taken) {
+			t.Errorf("%v: want some clipped actions, have %v of %v", name,
+				clipped, len(taken))
+		}
+
+		again := takenActions(1)
+		for i := range taken {
+			if taken[i] != again[i] {
+				t.Fatalf("%v: noisy actions not reproducible with a seed",
+					name)
+			}
+		}
+
+		gogym.SetSeed(7)
+		taken = takenActions(-1)
+		gogym.SetSeed(7)
+		again = takenActions(-1)
+		for i := range taken {
+			if taken[i] != again[i] {
+				t.Fatalf("%v: noisy actions not reproducible with the "+
+					"package seed", name)
+			}
+		}
+	}
+
+	discrete, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	if _, err = wrappers.NewGaussianActionNoise(newGoEnvironment(t,
+		discrete, 10), 0.1); err == nil {
+		t.Errorf("newGaussianActionNoise: expected error for DiscreteSpace " +
+			"actions")
+	}
+}
+
+func TestActionNoiseRescaleAction(t *testing.T) {
+	env, err := gogym.Make("MountainCarContinuous-v0")
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+
+	// Noise is added in the rescaled action space, and the rescaled
+	// actions are clipped before reaching the Python environment
+	env, err = wrappers.NewClipAction(env)
+	if err != nil {
+		t.Fatalf("newClipAction: %v", err)
+	}
+	env, err = wrappers.NewRescaleAction(env, -2, 2)
+	if err != nil {
+		t.Fatalf("newRescaleAction: %v", err)
+	}
+	env, err = wrappers.NewGaussianActionNoise(env, 0.1)
+	if err != nil {
+		t.Fatalf("newGaussianActionNoise: %v", err)
+	}
+
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{2})); err != nil {
+		t.Errorf("step: %v", err)
+	}
+
+	// Python wrappers cannot wrap ActionNoise, which would drop the noise
+	if _, err = wrappers.NewClipAction(env); err == nil {
+		t.Errorf("newClipAction: expected error when wrapping ActionNoise")
+	}
+	env.Close()
+}
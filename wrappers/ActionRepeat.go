@@ -0,0 +1,107 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// StepsTakenKey is the key in the info dictionary at which ActionRepeat
+// stores the number of steps taken in the wrapped environment on the
+// last step, as an int
+const StepsTakenKey = "steps_taken"
+
+// ActionRepeat wraps a gogym.Environment and repeats each action for a
+// number of steps in the wrapped environment, returning the sum of
+// rewards over all steps. Repetition stops early if the episode ends.
+// The observation returned is the last observation of the wrapped
+// environment, or optionally the elementwise maximum of the last two
+// observations.
+type ActionRepeat struct {
+	gogym.Environment
+	k       int
+	maxPool bool
+
+	// info is the info dictionary of the last step
+	info map[string]interface{}
+}
+
+// NewActionRepeat returns a new ActionRepeat which repeats each action
+// taken in env k times. If maxPool is true, then each observation is
+// the elementwise maximum of the last two observations of env.
+func NewActionRepeat(env gogym.Environment, k int,
+	maxPool bool) (gogym.Environment, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("newActionRepeat: k must be positive, got %v",
+			k)
+	}
+
+	return &ActionRepeat{
+		Environment: env,
+		k:           k,
+		maxPool:     maxPool,
+	}, nil
+}
+
+// Reset resets the environment and returns the starting state
+func (r *ActionRepeat) Reset() (*mat.VecDense, error) {
+	obs, err := r.Environment.Reset()
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+	r.info = nil
+	return obs, nil
+}
+
+// Step repeats action a k times, or until the episode ends, and
+// returns the sum of rewards over all steps
+func (r *ActionRepeat) Step(a *mat.VecDense) (*mat.VecDense, float64, bool,
+	error) {
+	var last, previous *mat.VecDense
+	var totalReward float64
+	var done bool
+	var steps int
+	for steps < r.k && !done {
+		obs, reward, stepDone, err := r.Environment.Step(a)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("step: %v", err)
+		}
+		previous, last = last, obs
+		totalReward += reward
+		done = stepDone
+		steps++
+	}
+
+	// Copy the wrapped info dictionary, which should not be modified
+	r.info = make(map[string]interface{})
+	for key, value := range gogym.Info(r.Environment) {
+		r.info[key] = value
+	}
+	r.info[StepsTakenKey] = steps
+
+	if r.maxPool && previous != nil {
+		pooled := mat.NewVecDense(last.Len(), nil)
+		for i := 0; i < last.Len(); i++ {
+			pooled.SetVec(i, math.Max(last.AtVec(i), previous.AtVec(i)))
+		}
+		last = pooled
+	}
+	return last, totalReward, done, nil
+}
+
+// Info returns the info dictionary of the wrapped environment after
+// the last step, including the number of steps taken at StepsTakenKey
+func (r *ActionRepeat) Info() map[string]interface{} {
+	if r.info != nil {
+		return r.info
+	}
+	return gogym.Info(r.Environment)
+}
+
+// Env returns nil, since an ActionRepeat has no Python environment
+func (r *ActionRepeat) Env() *python.PyObject {
+	return nil
+}
@@ -0,0 +1,67 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewActionRepeat(t *testing.T) {
+	box, err := gogym.NewBox([]float64{-10}, []float64{10}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	goEnv := newGoEnvironment(t, box, 5)
+
+	env, err := wrappers.NewActionRepeat(goEnv, 2, true)
+	if err != nil {
+		t.Fatalf("newActionRepeat: %v", err)
+	}
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	// Observations of goEnvironment are [t, sum of actions], so the
+	// maximum of the last two observations has the smaller sum for
+	// negative actions
+	action := mat.NewVecDense(1, []float64{-1})
+	tests := []struct {
+		obs    []float64
+		reward float64
+		done   bool
+		steps  int
+	}{
+		{[]float64{2, -1}, 2, false, 2},
+		{[]float64{4, -3}, 2, false, 2},
+		{[]float64{5, -5}, 1, true, 1},
+	}
+	for _, test := range tests {
+		obs, reward, done, err := env.Step(action)
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		if !floats.Equal(obs.RawVector().Data, test.obs) {
+			t.Errorf("step: want(%v) have(%v)", test.obs,
+				obs.RawVector().Data)
+		}
+		if reward != test.reward || done != test.done {
+			t.Errorf("step: want reward(%v) done(%v) have(%v) (%v)",
+				test.reward, test.done, reward, done)
+		}
+		if steps := gogym.Info(env)[wrappers.StepsTakenKey]; steps != test.steps {
+			t.Errorf("info: want steps taken(%v) have(%v)", test.steps,
+				steps)
+		}
+	}
+	if len(goEnv.actions) != 5 {
+		t.Errorf("step: want 5 steps in wrapped environment, have %v",
+			len(goEnv.actions))
+	}
+
+	if _, err = wrappers.NewActionRepeat(goEnv, 0, false); err == nil {
+		t.Errorf("newActionRepeat: expected error for k = 0")
+	}
+}
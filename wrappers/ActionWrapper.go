@@ -0,0 +1,91 @@
+package wrappers
+
+import (
+	"fmt"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ActionFunc transforms an action of the wrapper into an action of the
+// wrapped environment
+type ActionFunc func(a *mat.VecDense) (*mat.VecDense, error)
+
+// ActionWrapper wraps a gogym.Environment and transforms each action
+// taken in the wrapper using an ActionFunc before it is taken in the
+// wrapped environment. It is the Go equivalent of gym.ActionWrapper.
+//
+// As with ObservationWrapper, new action wrappers pass their
+// transformation to NewActionWrapper and may embed the returned
+// *ActionWrapper. Go has no virtual methods, so an Action method
+// defined on the embedding type is not called by Step.
+//
+// https://github.com/openai/gym/blob/master/gym/core.py
+type ActionWrapper struct {
+	gogym.Environment
+	actionSpace gogym.Space
+	action      ActionFunc
+}
+
+// NewActionWrapper returns a new ActionWrapper which transforms the
+// actions taken in the wrapper using f before they are taken in env.
+// The argument space is the action space of the wrapper, and f should
+// map each action in space to an action in the action space of env.
+// If space is nil, then the action space of env is used.
+func NewActionWrapper(env gogym.Environment, space gogym.Space,
+	f ActionFunc) (*ActionWrapper, error) {
+	if f == nil {
+		return nil, fmt.Errorf("newActionWrapper: action function cannot " +
+			"be nil")
+	}
+	if space == nil {
+		space = env.ActionSpace()
+	}
+
+	return &ActionWrapper{
+		Environment: env,
+		actionSpace: space,
+		action:      f,
+	}, nil
+}
+
+// ActionSpace returns the action space of the wrapper
+func (w *ActionWrapper) ActionSpace() gogym.Space {
+	return w.actionSpace
+}
+
+// ContinuousAction returns whether or not the wrapper has continuous
+// actions
+func (w *ActionWrapper) ContinuousAction() bool {
+	_, ok := w.actionSpace.(*gogym.BoxSpace)
+	return ok
+}
+
+// Action returns the action taken in the wrapped environment when
+// action a is taken in the wrapper
+func (w *ActionWrapper) Action(a *mat.VecDense) (*mat.VecDense, error) {
+	return w.action(a)
+}
+
+// Step takes one environmental step given some action a, which is
+// transformed before it is taken in the wrapped environment
+func (w *ActionWrapper) Step(a *mat.VecDense) (*mat.VecDense, float64,
+	bool, error) {
+	action, err := w.Action(a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+	return w.Environment.Step(action)
+}
+
+// Env returns nil, since an ActionWrapper has no Python environment
+func (w *ActionWrapper) Env() *python.PyObject {
+	return nil
+}
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (w *ActionWrapper) Info() map[string]interface{} {
+	return gogym.Info(w.Environment)
+}
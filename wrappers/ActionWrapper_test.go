@@ -0,0 +1,57 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewActionWrapper(t *testing.T) {
+	box, err := gogym.NewBox([]float64{-1}, []float64{1}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	goEnv := newGoEnvironment(t, box, 10)
+
+	// Take discrete actions 0 and 1 as -1 and 1 in the wrapped
+	// environment
+	discrete, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	env, err := wrappers.NewActionWrapper(goEnv, discrete,
+		func(a *mat.VecDense) (*mat.VecDense, error) {
+			return mat.NewVecDense(1, []float64{2*a.AtVec(0) - 1}), nil
+		})
+	if err != nil {
+		t.Fatalf("newActionWrapper: %v", err)
+	}
+	if env.ActionSpace() != discrete || env.ContinuousAction() {
+		t.Errorf("actionSpace: want(%v) have(%v)", discrete,
+			env.ActionSpace())
+	}
+
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	for _, action := range []float64{0, 1, 1} {
+		_, _, _, err = env.Step(mat.NewVecDense(1, []float64{action}))
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+	}
+
+	want := []float64{-1, 1, 1}
+	for i := range want {
+		if goEnv.actions[i].AtVec(0) != want[i] {
+			t.Errorf("step: want action(%v) have(%v)", want[i],
+				goEnv.actions[i].AtVec(0))
+		}
+	}
+
+	if _, err = wrappers.NewActionWrapper(goEnv, nil, nil); err == nil {
+		t.Errorf("newActionWrapper: expected error for nil function")
+	}
+}
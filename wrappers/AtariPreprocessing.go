@@ -0,0 +1,357 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// livesKeys are the keys in the info dictionary of an Atari
+// environment at which the number of lives remaining may be stored
+var livesKeys = []string{"ale.lives", "lives"}
+
+// AtariPreprocessing wraps an Atari gogym.Environment and implements
+// the common preprocessing of Atari observations:
+//
+//   - A random number of no-op actions on reset, where the no-op action
+//     is the first action of the action space, which is NOOP in Atari
+//     environments
+//   - Frame skipping, with max-pooling over the last two frames
+//   - Conversion to grayscale
+//   - Resizing to a square image using area interpolation
+//   - Terminal signal on the loss of a life
+//   - Scaling of observations to [0, 1]
+//
+// Observations of the wrapped environment must be RGB images of shape
+// (height, width, 3) or grayscale images of shape (height, width).
+// Observations of the wrapper have shape (screenSize, screenSize) if
+// converted to grayscale, and (screenSize, screenSize, 3) otherwise.
+// Observations have data type Float32 if scaled, and Uint8 otherwise.
+//
+// Frames are max-pooled over the last two frames observed during a
+// step, including the last frame of an episode which ends during frame
+// skipping. The number of lives is read from the info dictionary of
+// the wrapped environment at "ale.lives" or "lives".
+//
+// Grayscale conversion and resizing are computed directly on the
+// flattened image observations, so OpenCV is not required.
+//
+// https://github.com/openai/gym/blob/master/gym/wrappers/atari_preprocessing.py
+type AtariPreprocessing struct {
+	gogym.Environment
+	observationSpace *gogym.BoxSpace
+	rng              *rand.Rand
+
+	noop               *mat.VecDense
+	noopMax            int
+	frameSkip          int
+	screenSize         int
+	terminalOnLifeLoss bool
+	grayscaleObs       bool
+	scaleObs           bool
+
+	// Dimensions of the wrapped observations
+	height, width, channels int
+
+	// Area interpolation weights for resizing rows and columns
+	rowWeights, colWeights *mat.Dense
+
+	// Number of lives remaining, or -1 if unknown
+	lives int
+}
+
+// NewAtariPreprocessing returns a new AtariPreprocessing wrapping env.
+//
+// On reset, a random number of no-op actions in [1, noopMax] is taken,
+// or none if noopMax is 0. Each action is repeated for frameSkip
+// frames, and observations are resized to screenSize x screenSize. If
+// terminalOnLifeLoss is true, then episodes end when a life is lost. If
+// grayscaleObs is true, then observations are converted to grayscale.
+// If scaleObs is true, then observations are scaled to [0, 1].
+//
+// The default settings in Python's OpenAI Gym are a noopMax of 30, a
+// frameSkip of 4, a screenSize of 84, terminalOnLifeLoss false,
+// grayscaleObs true, and scaleObs false.
+func NewAtariPreprocessing(env gogym.Environment, noopMax, frameSkip,
+	screenSize int, terminalOnLifeLoss, grayscaleObs,
+	scaleObs bool) (gogym.Environment, error) {
+	if noopMax < 0 {
+		return nil, fmt.Errorf("newAtariPreprocessing: noopMax must be "+
+			"non-negative, got %v", noopMax)
+	}
+	if frameSkip <= 0 {
+		return nil, fmt.Errorf("newAtariPreprocessing: frameSkip must be "+
+			"positive, got %v", frameSkip)
+	}
+	if screenSize <= 0 {
+		return nil, fmt.Errorf("newAtariPreprocessing: screenSize must be "+
+			"positive, got %v", screenSize)
+	}
+	var noop *mat.VecDense
+	if actionSpace, ok := env.ActionSpace().(*gogym.DiscreteSpace); ok {
+		noop = mat.NewVecDense(1, []float64{float64(actionSpace.Start())})
+	} else if noopMax > 0 {
+		return nil, fmt.Errorf("newAtariPreprocessing: action space "+
+			"should be a DiscreteSpace for no-op resets but got %T",
+			env.ActionSpace())
+	}
+
+	box, ok := env.ObservationSpace().(*gogym.BoxSpace)
+	if !ok {
+		return nil, fmt.Errorf("newAtariPreprocessing: observation space "+
+			"should be a BoxSpace but got %T", env.ObservationSpace())
+	}
+	shape := box.Shape()
+	var height, width, channels int
+	switch {
+	case len(shape) == 2:
+		height, width, channels = shape[0], shape[1], 1
+	case len(shape) == 3 && shape[2] == 3:
+		height, width, channels = shape[0], shape[1], 3
+	default:
+		return nil, fmt.Errorf("newAtariPreprocessing: observations should "+
+			"have shape (height, width) or (height, width, 3) but got %v",
+			shape)
+	}
+
+	// Create the observation space
+	outChannels := channels
+	if grayscaleObs {
+		outChannels = 1
+	}
+	outShape := []int{screenSize, screenSize}
+	if outChannels == 3 {
+		outShape = append(outShape, 3)
+	}
+	dtype, high := gogym.Uint8, 255.0
+	if scaleObs {
+		dtype, high = gogym.Float32, 1.0
+	}
+	size := screenSize * screenSize * outChannels
+	lowBound := make([]float64, size)
+	highBound := make([]float64, size)
+	for i := range highBound {
+		highBound[i] = high
+	}
+	space, err := gogym.NewTypedBox(lowBound, highBound, outShape, dtype)
+	if err != nil {
+		return nil, fmt.Errorf("newAtariPreprocessing: could not create "+
+			"observation space: %v", err)
+	}
+
+	return &AtariPreprocessing{
+		Environment:        env,
+		observationSpace:   space,
+		rng:                rand.New(rand.NewSource(gogym.NewSeed())),
+		noop:               noop,
+		noopMax:            noopMax,
+		frameSkip:          frameSkip,
+		screenSize:         screenSize,
+		terminalOnLifeLoss: terminalOnLifeLoss,
+		grayscaleObs:       grayscaleObs,
+		scaleObs:           scaleObs,
+		height:             height,
+		width:              width,
+		channels:           channels,
+		rowWeights:         areaWeights(height, screenSize),
+		colWeights:         areaWeights(width, screenSize),
+		lives:              -1,
+	}, nil
+}
+
+// ObservationSpace returns the observation space of the preprocessed
+// observations
+func (a *AtariPreprocessing) ObservationSpace() gogym.Space {
+	return a.observationSpace
+}
+
+// Seed seeds the wrapped environment and the random number of no-op
+// actions taken on reset
+func (a *AtariPreprocessing) Seed(seed int) ([]int, error) {
+	a.rng.Seed(uint64(seed))
+	return a.Environment.Seed(seed)
+}
+
+// Reset resets the environment, takes a random number of no-op
+// actions, and returns the preprocessed starting state
+func (a *AtariPreprocessing) Reset() (*mat.VecDense, error) {
+	obs, err := a.Environment.Reset()
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+
+	noops := 0
+	if a.noopMax > 0 {
+		noops = a.rng.Intn(a.noopMax) + 1
+	}
+	for i := 0; i < noops; i++ {
+		var done bool
+		obs, _, done, err = a.Environment.Step(a.noop)
+		if err != nil {
+			return nil, fmt.Errorf("reset: could not take no-op action: %v",
+				err)
+		}
+		if done {
+			obs, err = a.Environment.Reset()
+			if err != nil {
+				return nil, fmt.Errorf("reset: %v", err)
+			}
+		}
+	}
+
+	a.lives = -1
+	if lives, ok := livesFromInfo(gogym.Info(a.Environment)); ok {
+		a.lives = lives
+	}
+
+	obs, err = a.Observation(obs, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+	return obs, nil
+}
+
+// Step repeats some action for frameSkip frames, or until the episode
+// ends, and returns the preprocessed observation and the sum of
+// rewards over all frames
+func (a *AtariPreprocessing) Step(action *mat.VecDense) (*mat.VecDense,
+	float64, bool, error) {
+	var last, previous *mat.VecDense
+	var totalReward float64
+	var done bool
+	for t := 0; t < a.frameSkip; t++ {
+		obs, reward, gameOver, err := a.Environment.Step(action)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("step: %v", err)
+		}
+		totalReward += reward
+		previous, last = last, obs
+		done = gameOver
+
+		if a.terminalOnLifeLoss {
+			lives, ok := livesFromInfo(gogym.Info(a.Environment))
+			if ok {
+				done = done || (a.lives >= 0 && lives < a.lives)
+				a.lives = lives
+			}
+		}
+
+		if done {
+			break
+		}
+	}
+
+	obs, err := a.Observation(last, previous)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+	return obs, totalReward, done, nil
+}
+
+// Observation returns the preprocessed observation for the last two
+// frames, last and previous, of the wrapped environment. If previous
+// is nil, then only the last frame is used.
+func (a *AtariPreprocessing) Observation(last,
+	previous *mat.VecDense) (*mat.VecDense, error) {
+	size := a.height * a.width * a.channels
+	if last.Len() != size || (previous != nil && previous.Len() != size) {
+		return nil, fmt.Errorf("observation: frames should have %v "+
+			"elements", size)
+	}
+
+	// Max-pool over the last two frames
+	frame := mat.VecDenseCopyOf(last)
+	if previous != nil {
+		for i := 0; i < frame.Len(); i++ {
+			frame.SetVec(i, math.Max(frame.AtVec(i), previous.AtVec(i)))
+		}
+	}
+
+	// Separate the channels, converting to grayscale if needed
+	var images []*mat.Dense
+	data := frame.RawVector().Data
+	if a.channels == 3 && a.grayscaleObs {
+		image := mat.NewDense(a.height, a.width, nil)
+		for i := 0; i < a.height*a.width; i++ {
+			gray := 0.299*data[3*i] + 0.587*data[3*i+1] + 0.114*data[3*i+2]
+			image.Set(i/a.width, i%a.width, gray)
+		}
+		images = append(images, image)
+	} else {
+		for c := 0; c < a.channels; c++ {
+			image := mat.NewDense(a.height, a.width, nil)
+			for i := 0; i < a.height*a.width; i++ {
+				image.Set(i/a.width, i%a.width, data[a.channels*i+c])
+			}
+			images = append(images, image)
+		}
+	}
+
+	// Resize each channel and interleave the channels
+	outChannels := len(images)
+	out := make([]float64, a.screenSize*a.screenSize*outChannels)
+	var resized, tmp mat.Dense
+	for c, image := range images {
+		tmp.Mul(a.rowWeights, image)
+		resized.Mul(&tmp, a.colWeights.T())
+
+		for i := 0; i < a.screenSize; i++ {
+			for j := 0; j < a.screenSize; j++ {
+				pixel := math.Round(resized.At(i, j))
+				if a.scaleObs {
+					pixel /= 255.0
+				}
+				out[(i*a.screenSize+j)*outChannels+c] = pixel
+			}
+		}
+		resized.Reset()
+		tmp.Reset()
+	}
+	return mat.NewVecDense(len(out), out), nil
+}
+
+// areaWeights returns the weights of area interpolation for resizing
+// an axis of length in to length out. Element (i, j) of the returned
+// matrix is the fraction of output pixel i covered by input pixel j.
+func areaWeights(in, out int) *mat.Dense {
+	weights := mat.NewDense(out, in, nil)
+	scale := float64(in) / float64(out)
+	for i := 0; i < out; i++ {
+		start, end := float64(i)*scale, float64(i+1)*scale
+		for j := int(start); j < in && float64(j) < end; j++ {
+			overlap := math.Min(end, float64(j+1)) -
+				math.Max(start, float64(j))
+			weights.Set(i, j, overlap/scale)
+		}
+	}
+	return weights
+}
+
+// livesFromInfo returns the number of lives remaining stored in the
+// info dictionary of an Atari environment, and whether it was found
+func livesFromInfo(info map[string]interface{}) (int, bool) {
+	for _, key := range livesKeys {
+		switch lives := info[key].(type) {
+		case int:
+			return lives, true
+		case float64:
+			return int(lives), true
+		}
+	}
+	return 0, false
+}
+
+// Env returns nil, since an AtariPreprocessing has no Python environment
+func (a *AtariPreprocessing) Env() *python.PyObject {
+	return nil
+}
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (a *AtariPreprocessing) Info() map[string]interface{} {
+	return gogym.Info(a.Environment)
+}
@@ -0,0 +1,246 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// atariEnvironment is a deterministic gogym.Environment implemented in
+// Go with RGB image observations of shape (4, 4, 3), used to test
+// Atari wrappers. After t steps, each channel of pixel (h, w) is
+// 40*(h/2) + 80*(w/2) + t, so that each 2 x 2 block of the image is
+// constant. A reward of 1 is given on each step, a life is lost every
+// 5 steps, and episodes end after 100 steps.
+type atariEnvironment struct {
+	actionSpace      *gogym.DiscreteSpace
+	observationSpace *gogym.BoxSpace
+
+	t       int
+	info    map[string]interface{}
+	actions []int // All actions taken
+}
+
+// newAtariEnvironment returns a new atariEnvironment
+func newAtariEnvironment(t *testing.T) *atariEnvironment {
+	actionSpace, err := gogym.NewDiscrete(4)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+
+	low := make([]float64, 4*4*3)
+	high := make([]float64, 4*4*3)
+	for i := range high {
+		high[i] = 255
+	}
+	obsSpace, err := gogym.NewTypedBox(low, high, []int{4, 4, 3},
+		gogym.Uint8)
+	if err != nil {
+		t.Fatalf("newTypedBox: %v", err)
+	}
+
+	return &atariEnvironment{
+		actionSpace:      actionSpace,
+		observationSpace: obsSpace,
+		info:             make(map[string]interface{}),
+	}
+}
+
+func (a *atariEnvironment) Env() *python.PyObject { return nil }
+
+func (a *atariEnvironment) Name() string { return "AtariEnvironment" }
+
+func (a *atariEnvironment) ContinuousAction() bool { return false }
+
+func (a *atariEnvironment) Seed(seed int) ([]int, error) {
+	return []int{seed}, nil
+}
+
+func (a *atariEnvironment) ActionSpace() gogym.Space { return a.actionSpace }
+
+func (a *atariEnvironment) ObservationSpace() gogym.Space {
+	return a.observationSpace
+}
+
+func (a *atariEnvironment) Step(action *mat.VecDense) (*mat.VecDense,
+	float64, bool, error) {
+	a.actions = append(a.actions, int(action.AtVec(0)))
+	a.t++
+	a.info = map[string]interface{}{"ale.lives": 3 - a.t/5}
+	return a.obs(), 1.0, a.t >= 100, nil
+}
+
+func (a *atariEnvironment) Reset() (*mat.VecDense, error) {
+	a.t = 0
+	a.info = map[string]interface{}{"ale.lives": 3}
+	return a.obs(), nil
+}
+
+func (a *atariEnvironment) Info() map[string]interface{} { return a.info }
+
+func (a *atariEnvironment) Close() {}
+
+// obs returns the current observation
+func (a *atariEnvironment) obs() *mat.VecDense {
+	data := make([]float64, 0, 4*4*3)
+	for h := 0; h < 4; h++ {
+		for w := 0; w < 4; w++ {
+			pixel := float64(40*(h/2) + 80*(w/2) + a.t)
+			data = append(data, pixel, pixel, pixel)
+		}
+	}
+	return mat.NewVecDense(len(data), data)
+}
+
+func TestNewAtariPreprocessing(t *testing.T) {
+	atariEnv := newAtariEnvironment(t)
+
+	// Grayscale observations with frame skipping and terminal on life
+	// loss
+	env, err := wrappers.NewAtariPreprocessing(atariEnv, 0, 2, 2, true,
+		true, false)
+	if err != nil {
+		t.Fatalf("newAtariPreprocessing: %v", err)
+	}
+
+	space := env.ObservationSpace().(*gogym.BoxSpace)
+	if shape := space.Shape(); len(shape) != 2 || shape[0] != 2 ||
+		shape[1] != 2 {
+		t.Errorf("observationSpace: want shape([2 2]) have(%v)", shape)
+	}
+	if space.DType() != gogym.Uint8 {
+		t.Errorf("observationSpace: want dtype(%v) have(%v)", gogym.Uint8,
+			space.DType())
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if want := []float64{0, 80, 40, 120}; !floats.Equal(
+		obs.RawVector().Data, want) {
+		t.Errorf("reset: want(%v) have(%v)", want, obs.RawVector().Data)
+	}
+
+	// A life is lost on the fifth frame, ending the third step early
+	action := mat.NewVecDense(1, []float64{1})
+	tests := []struct {
+		obs    []float64
+		reward float64
+		done   bool
+	}{
+		{[]float64{2, 82, 42, 122}, 2, false},
+		{[]float64{4, 84, 44, 124}, 2, false},
+		{[]float64{5, 85, 45, 125}, 1, true},
+	}
+	for _, test := range tests {
+		obs, reward, done, err := env.Step(action)
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		if !floats.Equal(obs.RawVector().Data, test.obs) {
+			t.Errorf("step: want(%v) have(%v)", test.obs,
+				obs.RawVector().Data)
+		}
+		if reward != test.reward || done != test.done {
+			t.Errorf("step: want reward(%v) done(%v) have(%v) (%v)",
+				test.reward, test.done, reward, done)
+		}
+		if !space.Contains(obs) {
+			t.Errorf("step: observation %v not in observation space",
+				obs.RawVector().Data)
+		}
+	}
+
+	// Scaled RGB observations
+	env, err = wrappers.NewAtariPreprocessing(atariEnv, 0, 1, 2, false,
+		false, true)
+	if err != nil {
+		t.Fatalf("newAtariPreprocessing: %v", err)
+	}
+	space = env.ObservationSpace().(*gogym.BoxSpace)
+	if shape := space.Shape(); len(shape) != 3 || shape[2] != 3 {
+		t.Errorf("observationSpace: want shape([2 2 3]) have(%v)", shape)
+	}
+	if space.DType() != gogym.Float32 {
+		t.Errorf("observationSpace: want dtype(%v) have(%v)",
+			gogym.Float32, space.DType())
+	}
+	obs, err = env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if obs.Len() != 12 || obs.AtVec(3) != 80.0/255.0 ||
+		obs.AtVec(5) != 80.0/255.0 {
+		t.Errorf("reset: unexpected scaled observation %v",
+			obs.RawVector().Data)
+	}
+
+	// No-op resets are reproducible with a seed
+	env, err = wrappers.NewAtariPreprocessing(atariEnv, 10, 1, 2, false,
+		true, false)
+	if err != nil {
+		t.Fatalf("newAtariPreprocessing: %v", err)
+	}
+	var noops []int
+	for i := 0; i < 2; i++ {
+		env.Seed(42)
+		if _, err = env.Reset(); err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+		if atariEnv.t < 1 || atariEnv.t > 10 {
+			t.Errorf("reset: want no-ops in [1, 10] have(%v)", atariEnv.t)
+		}
+		noops = append(noops, atariEnv.t)
+	}
+	if noops[0] != noops[1] {
+		t.Errorf("seed: no-op resets not reproducible, have %v", noops)
+	}
+
+	// No-op resets are reproducible with the package seed
+	noops = nil
+	for i := 0; i < 2; i++ {
+		gogym.SetSeed(42)
+		env, err = wrappers.NewAtariPreprocessing(atariEnv, 10, 1, 2, false,
+			true, false)
+		if err != nil {
+			t.Fatalf("newAtariPreprocessing: %v", err)
+		}
+		if _, err = env.Reset(); err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+		noops = append(noops, atariEnv.t)
+	}
+	if noops[0] != noops[1] {
+		t.Errorf("setSeed: no-op resets not reproducible, have %v", noops)
+	}
+
+	// The no-op action is the first action of the action space
+	atariEnv.actionSpace, err = gogym.NewDiscreteStart(4, 2)
+	if err != nil {
+		t.Fatalf("newDiscreteStart: %v", err)
+	}
+	env, err = wrappers.NewAtariPreprocessing(atariEnv, 10, 1, 2, false,
+		true, false)
+	if err != nil {
+		t.Fatalf("newAtariPreprocessing: %v", err)
+	}
+	atariEnv.actions = nil
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	for _, action := range atariEnv.actions {
+		if action != 2 {
+			t.Errorf("reset: want no-op action 2 have(%v)", action)
+		}
+	}
+
+	if _, err = wrappers.NewAtariPreprocessing(atariEnv, 0, 0, 84, false,
+		true, false); err == nil {
+		t.Errorf("newAtariPreprocessing: expected error for frameSkip 0")
+	}
+}
@@ -7,28 +7,9 @@ import (
 	"github.com/samuelfneumann/gogym"
 )
 
-// wrappers.clip_action Python module
+// gym.wrappers.clip_action Python module, imported by importModule
 var clipActionModule *python.PyObject
 
-// init performs setup before running
-func init() {
-	// Create the gym.wrappers.clip_action Python module
-	wrappersModule := python.PyImport_ImportModule("gym.wrappers.clip_action")
-	defer wrappersModule.DecRef()
-	if wrappersModule == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		panic("init: could not import gym.wrappers.clip_action")
-	}
-	clipActionModule = python.PyImport_AddModule("gym.wrappers.clip_action")
-	wrappersModule.IncRef()
-}
-
 // ClipAction wraps a gogym.Environment and clips the continuous action
 // within the valid bounds.
 //
@@ -41,18 +22,21 @@ type ClipAction struct {
 // NewClipAction returns a new gogym.Environment that clips the actions
 // taken in env.
 func NewClipAction(env gogym.Environment) (gogym.Environment, error) {
+	pyEnv, err := pythonEnv(env)
+	if err != nil {
+		return nil, fmt.Errorf("clipAction: %v", err)
+	}
+	if err = importModule(&clipActionModule,
+		"gym.wrappers.clip_action"); err != nil {
+		return nil, fmt.Errorf("clipAction: %v", err)
+	}
+
 	// Call the ClipAction constructor with the argument environment
-	newEnv := clipActionModule.CallMethodArgs("ClipAction", env.Env())
+	newEnv := clipActionModule.CallMethodArgs("ClipAction", pyEnv)
 	defer newEnv.DecRef()
 	if newEnv == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		return nil, fmt.Errorf("clipAction: could not wrap environment")
+		return nil, fmt.Errorf("clipAction: could not wrap environment: %v",
+			pythonError())
 	}
 
 	// Create the new gogym Environment
@@ -80,3 +64,9 @@ func (c *ClipAction) Close() {
 	// Close this environment
 	c.Environment.Close()
 }
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (c *ClipAction) Info() map[string]interface{} {
+	return gogym.Info(c.Environment)
+}
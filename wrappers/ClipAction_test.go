@@ -3,6 +3,7 @@ package wrappers_test
 import (
 	"testing"
 
+	python "github.com/DataDog/go-python3"
 	"github.com/samuelfneumann/gogym"
 	"github.com/samuelfneumann/gogym/wrappers"
 	"gonum.org/v1/gonum/mat"
@@ -40,3 +41,88 @@ func TestNewClipAction(t *testing.T) {
 
 	env.Close()
 }
+
+// pythonBackedEnvironment is a goEnvironment which claims to be backed
+// by a Python environment, so that wrappers can be checked for hiding
+// it without calling into Python
+type pythonBackedEnvironment struct {
+	*goEnvironment
+	pyEnv *python.PyObject
+}
+
+func (p *pythonBackedEnvironment) Env() *python.PyObject { return p.pyEnv }
+
+func TestClipActionGoWrapper(t *testing.T) {
+	actionSpace, err := gogym.NewBox([]float64{-1}, []float64{1}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	env := &pythonBackedEnvironment{
+		goEnvironment: newGoEnvironment(t, actionSpace, 10),
+		pyEnv:         new(python.PyObject),
+	}
+	identity := func(v *mat.VecDense) (*mat.VecDense, error) { return v, nil }
+
+	// Go wrappers must hide the Python environment, otherwise Python
+	// wrappers on top of them would silently drop them
+	goWrappers := map[string]func() (gogym.Environment, error){
+		"ObservationWrapper": func() (gogym.Environment, error) {
+			return wrappers.NewObservationWrapper(env, nil, identity)
+		},
+		"ActionWrapper": func() (gogym.Environment, error) {
+			return wrappers.NewActionWrapper(env, nil, identity)
+		},
+		"RewardWrapper": func() (gogym.Environment, error) {
+			return wrappers.NewRewardWrapper(env,
+				func(r float64) float64 { return r })
+		},
+		"RecordEpisodeStatistics": func() (gogym.Environment, error) {
+			return wrappers.NewRecordEpisodeStatistics(env, 10)
+		},
+		"NormalizeReward": func() (gogym.Environment, error) {
+			return wrappers.NewNormalizeReward(env, 0.99, 1e-8)
+		},
+		"FrameStack": func() (gogym.Environment, error) {
+			return wrappers.NewFrameStack(env, 2)
+		},
+		"ActionRepeat": func() (gogym.Environment, error) {
+			return wrappers.NewActionRepeat(env, 2, false)
+		},
+		"GaussianActionNoise": func() (gogym.Environment, error) {
+			return wrappers.NewGaussianActionNoise(env, 0.1)
+		},
+	}
+	for name, newWrapper := range goWrappers {
+		wrapped, err := newWrapper()
+		if err != nil {
+			t.Fatalf("new%v: %v", name, err)
+		}
+		if wrapped.Env() != nil {
+			t.Errorf("%v: env: want(nil) have(%v)", name, wrapped.Env())
+		}
+		if _, err = wrappers.NewClipAction(wrapped); err == nil {
+			t.Errorf("newClipAction: expected error when wrapping %v", name)
+		}
+		if _, err = wrappers.NewRescaleAction(wrapped, -1, 1); err == nil {
+			t.Errorf("newRescaleAction: expected error when wrapping %v",
+				name)
+		}
+		if _, err = wrappers.NewTimeLimit(wrapped, 5); err == nil {
+			t.Errorf("newTimeLimit: expected error when wrapping %v", name)
+		}
+		if _, err = wrappers.NewFlattenObservation(wrapped); err == nil {
+			t.Errorf("newFlattenObservation: expected error when "+
+				"wrapping %v", name)
+		}
+		if _, err = wrappers.NewFilterObservation(wrapped); err == nil {
+			t.Errorf("newFilterObservation: expected error when "+
+				"wrapping %v", name)
+		}
+	}
+
+	// Environments implemented in Go cannot be wrapped either
+	if _, err = wrappers.NewClipAction(env.goEnvironment); err == nil {
+		t.Errorf("newClipAction: expected error when wrapping Go " +
+			"environment")
+	}
+}
@@ -0,0 +1,38 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gogym"
+)
+
+// ClipReward wraps a gogym.Environment and clips each reward to
+// [min, max].
+type ClipReward struct {
+	*RewardWrapper
+	min, max float64
+}
+
+// NewClipReward returns a new ClipReward which clips the rewards of env
+// to [min, max]. Either bound may be infinite.
+func NewClipReward(env gogym.Environment, min,
+	max float64) (gogym.Environment, error) {
+	if min > max {
+		return nil, fmt.Errorf("newClipReward: min (%v) must not be larger "+
+			"than max (%v)", min, max)
+	}
+
+	c := &ClipReward{min: min, max: max}
+	var err error
+	c.RewardWrapper, err = NewRewardWrapper(env, c.clip)
+	if err != nil {
+		return nil, fmt.Errorf("newClipReward: %v", err)
+	}
+	return c, nil
+}
+
+// clip clips reward to [min, max]
+func (c *ClipReward) clip(reward float64) float64 {
+	return math.Max(c.min, math.Min(c.max, reward))
+}
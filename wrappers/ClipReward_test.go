@@ -0,0 +1,49 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewClipReward(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	// goEnvironment gives a reward of 1 on each step
+	tests := []struct {
+		min, max, want float64
+	}{
+		{-1, 1, 1},
+		{-0.5, 0.5, 0.5},
+		{2, 3, 2},
+	}
+
+	for _, test := range tests {
+		env, err := wrappers.NewClipReward(goEnv, test.min, test.max)
+		if err != nil {
+			t.Fatalf("newClipReward: %v", err)
+		}
+		if _, err = env.Reset(); err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+
+		_, reward, _, err := env.Step(mat.NewVecDense(1, []float64{0}))
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		if reward != test.want {
+			t.Errorf("step: want reward(%v) have(%v) for bounds [%v, %v]",
+				test.want, reward, test.min, test.max)
+		}
+	}
+
+	if _, err = wrappers.NewClipReward(goEnv, 1, -1); err == nil {
+		t.Errorf("newClipReward: expected error for min > max")
+	}
+}
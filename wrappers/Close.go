@@ -1,8 +1,18 @@
 // Package wrappers implements Go bindings for the environment wrappers
 // in OpenAI's Gym.
+//
+// Some wrappers, such as ClipAction and TimeLimit, call the wrapper of
+// the same name in Python and wrap the Python environment returned by
+// the Env method of the wrapped gogym.Environment. The other wrappers
+// are implemented in Go and have no Python environment, so wrappers
+// which call Python must be applied before any wrappers implemented in
+// Go, and return an error otherwise.
 package wrappers
 
 import (
+	"fmt"
+
+	python "github.com/DataDog/go-python3"
 	"github.com/samuelfneumann/gogym"
 )
 
@@ -24,3 +34,60 @@ func Close() {
 
 	gogym.Close()
 }
+
+// importModule imports the Python module with the given name into
+// module if it has not been imported yet. Modules are imported lazily
+// so that the Go wrappers can be used without gym installed.
+func importModule(module **python.PyObject, name string) error {
+	if *module != nil {
+		return nil
+	}
+
+	imported := python.PyImport_ImportModule(name)
+	if imported == nil {
+		return fmt.Errorf("importModule: could not import %v: %v", name,
+			pythonError())
+	}
+	*module = imported
+	return nil
+}
+
+// pythonEnv returns the Python environment of env, for wrapping by a
+// Python gym wrapper. It returns an error if env has no Python
+// environment, for example if env is implemented in Go or is wrapped
+// by a wrapper implemented in Go, since the Python wrapper would
+// otherwise wrap the Python environment beneath the Go wrappers and
+// silently drop them.
+func pythonEnv(env gogym.Environment) (*python.PyObject, error) {
+	pyEnv := env.Env()
+	if pyEnv == nil {
+		return nil, fmt.Errorf("cannot wrap environment %v, which is not "+
+			"backed by Python: wrappers implemented in Python must be "+
+			"applied before any wrappers implemented in Go", env.Name())
+	}
+	return pyEnv, nil
+}
+
+// pythonError fetches and clears the pending Python exception and
+// returns it as an error
+func pythonError() error {
+	if python.PyErr_Occurred() == nil {
+		return fmt.Errorf("no Python exception set")
+	}
+	excType, value, traceback := python.PyErr_NormalizeException(
+		python.PyErr_Fetch())
+	defer excType.DecRef()
+	defer value.DecRef()
+	defer traceback.DecRef()
+
+	name := excType.GetAttrString("__name__")
+	defer name.DecRef()
+	message := value.Str()
+	defer message.DecRef()
+	if name == nil || message == nil {
+		python.PyErr_Clear()
+		return fmt.Errorf("could not get Python exception")
+	}
+	return fmt.Errorf("%v: %v", python.PyUnicode_AsUTF8(name),
+		python.PyUnicode_AsUTF8(message))
+}
@@ -0,0 +1,187 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// DiscretizeAction wraps a gogym.Environment with a BoxSpace action
+// space and exposes a DiscreteSpace action space instead. Each
+// discrete action is an index into a fixed list of continuous actions,
+// which is either a uniform grid over the bounds of the BoxSpace or a
+// list of actions given by the user. The list of continuous actions
+// can be inspected with Actions and Continuous to interpret the
+// actions taken by an agent.
+//
+// The grid is built from the bounds of the wrapped action space when
+// the wrapper is constructed, so any RescaleAction wrapper must be
+// applied before DiscretizeAction for the grid to cover the rescaled
+// bounds.
+type DiscretizeAction struct {
+	*ActionWrapper
+	actionSpace *gogym.DiscreteSpace
+
+	// actions holds the continuous action for each discrete action
+	actions []*mat.VecDense
+}
+
+// NewDiscretizeAction returns a new DiscretizeAction which discretizes
+// the BoxSpace action space of env into a uniform grid. The argument
+// binsPerDim holds the number of evenly spaced values of each action
+// dimension, including both bounds, or a single number of values used
+// for all dimensions. A dimension with a single value takes the
+// midpoint of its bounds. The action space must be bounded.
+//
+// Discrete actions index the grid in row-major order, so that the last
+// action dimension varies fastest. For example, with bins [2, 3] over
+// [-1, 1] x [-1, 1], discrete action 1 is the continuous action
+// [-1, 0], and discrete action 3 is the continuous action [1, -1].
+func NewDiscretizeAction(env gogym.Environment,
+	binsPerDim []int) (gogym.Environment, error) {
+	box, ok := env.ActionSpace().(*gogym.BoxSpace)
+	if !ok {
+		return nil, fmt.Errorf("newDiscretizeAction: action space should "+
+			"be a BoxSpace but got %T", env.ActionSpace())
+	}
+	low, high := box.Low()[0], box.High()[0]
+	dims := low.Len()
+
+	if len(binsPerDim) == 1 && dims > 1 {
+		bins := make([]int, dims)
+		for i := range bins {
+			bins[i] = binsPerDim[0]
+		}
+		binsPerDim = bins
+	}
+	if len(binsPerDim) != dims {
+		return nil, fmt.Errorf("newDiscretizeAction: binsPerDim should "+
+			"have length 1 or %v but got %v", dims, len(binsPerDim))
+	}
+
+	// Compute the values of each dimension
+	values := make([][]float64, dims)
+	n := 1
+	for i, bins := range binsPerDim {
+		if bins <= 0 {
+			return nil, fmt.Errorf("newDiscretizeAction: bins must be "+
+				"positive, got %v", bins)
+		}
+		l, h := low.AtVec(i), high.AtVec(i)
+		if math.IsInf(l, 0) || math.IsInf(h, 0) {
+			return nil, fmt.Errorf("newDiscretizeAction: cannot discretize "+
+				"unbounded action dimension %v", i)
+		}
+
+		values[i] = make([]float64, bins)
+		if bins == 1 {
+			values[i][0] = (l + h) / 2
+			continue
+		}
+		for j := range values[i] {
+			values[i][j] = l + float64(j)*(h-l)/float64(bins-1)
+		}
+		n *= bins
+	}
+
+	// Construct the grid, with the last dimension varying fastest
+	actions := make([]*mat.VecDense, n)
+	for a := range actions {
+		action := mat.NewVecDense(dims, nil)
+		index := a
+		for i := dims - 1; i >= 0; i-- {
+			action.SetVec(i, values[i][index%len(values[i])])
+			index /= len(values[i])
+		}
+		actions[a] = action
+	}
+
+	d, err := newDiscretizeAction(env, actions)
+	if err != nil {
+		return nil, fmt.Errorf("newDiscretizeAction: %v", err)
+	}
+	return d, nil
+}
+
+// NewDiscretizeActionList returns a new DiscretizeAction which
+// discretizes the BoxSpace action space of env into the given list of
+// continuous actions. Discrete action i is the continuous action
+// actions[i], and each action must be in the action space of env.
+func NewDiscretizeActionList(env gogym.Environment,
+	actions []*mat.VecDense) (gogym.Environment, error) {
+	if _, ok := env.ActionSpace().(*gogym.BoxSpace); !ok {
+		return nil, fmt.Errorf("newDiscretizeActionList: action space "+
+			"should be a BoxSpace but got %T", env.ActionSpace())
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("newDiscretizeActionList: actions cannot " +
+			"be empty")
+	}
+
+	copies := make([]*mat.VecDense, len(actions))
+	for i, action := range actions {
+		if action == nil || !env.ActionSpace().Contains(action) {
+			return nil, fmt.Errorf("newDiscretizeActionList: action %v "+
+				"not in action space", i)
+		}
+		copies[i] = mat.VecDenseCopyOf(action)
+	}
+
+	d, err := newDiscretizeAction(env, copies)
+	if err != nil {
+		return nil, fmt.Errorf("newDiscretizeActionList: %v", err)
+	}
+	return d, nil
+}
+
+// newDiscretizeAction returns a new DiscretizeAction which maps
+// discrete action i to actions[i]
+func newDiscretizeAction(env gogym.Environment,
+	actions []*mat.VecDense) (*DiscretizeAction, error) {
+	actionSpace, err := gogym.NewDiscrete(len(actions))
+	if err != nil {
+		return nil, fmt.Errorf("could not create action space: %v", err)
+	}
+
+	d := &DiscretizeAction{
+		actionSpace: actionSpace,
+		actions:     actions,
+	}
+	d.ActionWrapper, err = NewActionWrapper(env, actionSpace, d.action)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// action returns the continuous action for discrete action a
+func (d *DiscretizeAction) action(a *mat.VecDense) (*mat.VecDense, error) {
+	if !d.actionSpace.Contains(a) {
+		return nil, fmt.Errorf("action: action %v not in action space",
+			a.RawVector().Data)
+	}
+	return mat.VecDenseCopyOf(d.actions[int(a.AtVec(0))]), nil
+}
+
+// Continuous returns the continuous action taken in the wrapped
+// environment when discrete action a is taken
+func (d *DiscretizeAction) Continuous(a int) (*mat.VecDense, error) {
+	if !d.actionSpace.Contains(a) {
+		return nil, fmt.Errorf("continuous: action %v not in action space",
+			a)
+	}
+	return mat.VecDenseCopyOf(d.actions[a]), nil
+}
+
+// Actions returns the continuous actions of the wrapper, where element
+// i is the continuous action taken in the wrapped environment when
+// discrete action i is taken
+func (d *DiscretizeAction) Actions() []*mat.VecDense {
+	actions := make([]*mat.VecDense, len(d.actions))
+	for i, action := range d.actions {
+		actions[i] = mat.VecDenseCopyOf(action)
+	}
+	return actions
+}
@@ -0,0 +1,139 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewDiscretizeAction(t *testing.T) {
+	actionSpace, err := gogym.NewBox([]float64{-1, 0}, []float64{1, 4}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	env, err := wrappers.NewDiscretizeAction(goEnv, []int{2, 3})
+	if err != nil {
+		t.Fatalf("newDiscretizeAction: %v", err)
+	}
+	if env.ContinuousAction() {
+		t.Errorf("continuousAction: want(false) have(true)")
+	}
+	space, ok := env.ActionSpace().(*gogym.DiscreteSpace)
+	if !ok || space.N() != 6 {
+		t.Fatalf("actionSpace: want Discrete(6) have(%v)", env.ActionSpace())
+	}
+
+	// The last dimension varies fastest
+	want := [][]float64{
+		{-1, 0}, {-1, 2}, {-1, 4},
+		{1, 0}, {1, 2}, {1, 4},
+	}
+	actions := env.(*wrappers.DiscretizeAction).Actions()
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	for i := range want {
+		if !floats.Equal(actions[i].RawVector().Data, want[i]) {
+			t.Errorf("actions: want action(%v) at index %v have(%v)", want[i],
+				i, actions[i].RawVector().Data)
+		}
+
+		_, _, _, err = env.Step(mat.NewVecDense(1, []float64{float64(i)}))
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		taken := goEnv.actions[len(goEnv.actions)-1].RawVector().Data
+		if !floats.Equal(taken, want[i]) {
+			t.Errorf("step: want continuous action(%v) have(%v)", want[i],
+				taken)
+		}
+	}
+
+	if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{6})); err == nil {
+		t.Errorf("step: expected error for action not in action space")
+	}
+
+	// A single number of bins is used for all dimensions, and a single
+	// bin takes the midpoint
+	env, err = wrappers.NewDiscretizeAction(goEnv, []int{1})
+	if err != nil {
+		t.Fatalf("newDiscretizeAction: %v", err)
+	}
+	action, err := env.(*wrappers.DiscretizeAction).Continuous(0)
+	if err != nil {
+		t.Fatalf("continuous: %v", err)
+	}
+	if want := []float64{0, 2}; !floats.Equal(action.RawVector().Data, want) {
+		t.Errorf("continuous: want(%v) have(%v)", want,
+			action.RawVector().Data)
+	}
+
+	if _, err = wrappers.NewDiscretizeAction(goEnv, []int{2, 2, 2}); err ==
+		nil {
+		t.Errorf("newDiscretizeAction: expected error for wrong number " +
+			"of dimensions")
+	}
+}
+
+func TestNewDiscretizeActionList(t *testing.T) {
+	actionSpace, err := gogym.NewBox([]float64{-2}, []float64{2}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	list := []*mat.VecDense{
+		mat.NewVecDense(1, []float64{-2}),
+		mat.NewVecDense(1, []float64{0.5}),
+	}
+	env, err := wrappers.NewDiscretizeActionList(goEnv, list)
+	if err != nil {
+		t.Fatalf("newDiscretizeActionList: %v", err)
+	}
+	list[1].SetVec(0, 2)
+
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	obs, _, _, err := env.Step(mat.NewVecDense(1, []float64{1}))
+	if err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if obs.AtVec(1) != 0.5 {
+		t.Errorf("step: want continuous action(0.5) have(%v)", obs.AtVec(1))
+	}
+
+	list[1].SetVec(0, 3)
+	if _, err = wrappers.NewDiscretizeActionList(goEnv, list); err == nil {
+		t.Errorf("newDiscretizeActionList: expected error for action not " +
+			"in action space")
+	}
+}
+
+func TestDiscretizeActionPendulum(t *testing.T) {
+	env, err := gogym.Make("Pendulum-v0")
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+	defer env.Close()
+
+	env, err = wrappers.NewDiscretizeAction(env, []int{5})
+	if err != nil {
+		t.Fatalf("newDiscretizeAction: %v", err)
+	}
+
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		_, _, _, err = env.Step(mat.NewVecDense(1, []float64{float64(i)}))
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+	}
+}
@@ -7,30 +7,9 @@ import (
 	"github.com/samuelfneumann/gogym"
 )
 
-// wrappers.filter_observation Python module
+// gym.wrappers.filter_observation Python module, imported by importModule
 var filterObservationModule *python.PyObject
 
-// init performs setup before running
-func init() {
-	// Create the gym.wrappers.filter_observation Python module
-	wrappersModule := python.PyImport_ImportModule("gym.wrappers." +
-		"filter_observation")
-	defer wrappersModule.DecRef()
-	if wrappersModule == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		panic("init: could not import gym.wrappers.filter_observation")
-	}
-	filterObservationModule = python.PyImport_AddModule("gym.wrappers." +
-		"filter_observation")
-	wrappersModule.IncRef()
-}
-
 // FilterObservation filters DictSpace environment observations
 // by their keys.
 //
@@ -50,6 +29,15 @@ type FilterObservation struct {
 // DictSpace.
 func NewFilterObservation(env gogym.Environment,
 	keys ...string) (gogym.Environment, error) {
+	pyEnv, err := pythonEnv(env)
+	if err != nil {
+		return nil, fmt.Errorf("newFilterObservation: %v", err)
+	}
+	if err = importModule(&filterObservationModule,
+		"gym.wrappers.filter_observation"); err != nil {
+		return nil, fmt.Errorf("newFilterObservation: %v", err)
+	}
+
 	// Ensure observation space is a DictSpace
 	_, isDictSpace := env.ObservationSpace().(*gogym.DictSpace)
 	if !isDictSpace {
@@ -59,7 +47,7 @@ func NewFilterObservation(env gogym.Environment,
 
 	// Create the arguments for the filter keys
 	pythonArgs := make([]*python.PyObject, len(keys)+1)
-	pythonArgs[0] = env.Env()
+	pythonArgs[0] = pyEnv
 	for i, key := range keys {
 		if i == 0 {
 			continue
@@ -73,16 +61,8 @@ func NewFilterObservation(env gogym.Environment,
 		pythonArgs...)
 	defer newEnv.DecRef()
 	if newEnv == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-
-		return nil, fmt.Errorf("newFilterObservation: could not wrap " +
-			"environment")
+		return nil, fmt.Errorf("newFilterObservation: could not wrap "+
+			"environment: %v", pythonError())
 	}
 
 	// Create the new observation space
@@ -127,3 +107,9 @@ func (f *FilterObservation) filterObservation(
 	}
 	return newObs
 }
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (f *FilterObservation) Info() map[string]interface{} {
+	return gogym.Info(f.Environment)
+}
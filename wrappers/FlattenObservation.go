@@ -7,30 +7,9 @@ import (
 	"github.com/samuelfneumann/gogym"
 )
 
-// wrappers.flatten_observation Python module
+// gym.wrappers.flatten_observation Python module, imported by importModule
 var flattenObservationModule *python.PyObject
 
-// init performs setup before running
-func init() {
-	// Create the gym.wrappers.flatten_observation Python module
-	wrappersModule := python.PyImport_ImportModule("gym.wrappers." +
-		"flatten_observation")
-	defer wrappersModule.DecRef()
-	if wrappersModule == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		panic("init: could not import gym.wrappers.flatten_observation")
-	}
-	flattenObservationModule = python.PyImport_AddModule("gym.wrappers." +
-		"flatten_observation")
-	wrappersModule.IncRef()
-}
-
 // FlattenObservation wraps a gogym.Environment and flattens the
 // observations.
 //
@@ -47,30 +26,42 @@ type FlattenObservation struct {
 // NewFlattenObservation returns a new gogym.Environment that flattens
 // state observations
 func NewFlattenObservation(env gogym.Environment) (gogym.Environment, error) {
+	pyEnv, err := pythonEnv(env)
+	if err != nil {
+		return nil, fmt.Errorf("newFlattenObservation: %v", err)
+	}
+	if err = importModule(&flattenObservationModule,
+		"gym.wrappers.flatten_observation"); err != nil {
+		return nil, fmt.Errorf("newFlattenObservation: %v", err)
+	}
+
 	// Call the FlattenObservation constructor with the argument environment
 	newEnv := flattenObservationModule.CallMethodArgs("FlattenObservation",
-		env.Env())
+		pyEnv)
 	defer newEnv.DecRef()
 	if newEnv == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		return nil, fmt.Errorf("newFlattenObservation: could not wrap " +
-			"environment")
+		return nil, fmt.Errorf("newFlattenObservation: could not wrap "+
+			"environment: %v", pythonError())
 	}
 
-	// Create the new observation space
-	pyObservationSpace := newEnv.GetAttrString("observation_space")
-	defer pyObservationSpace.DecRef()
-	obsSpace, err := gogym.SpaceFromPyObject(pyObservationSpace)
-	if err != nil {
-		return nil, fmt.Errorf("newFlattenObservation: could not get Python "+
-			"observation space: %v",
-			err)
+	// Create the new observation space. If the observation space of
+	// env could not be converted to Go, fall back to the observation
+	// space of the Python wrapper.
+	var obsSpace gogym.Space
+	if env.ObservationSpace() != nil {
+		obsSpace, err = gogym.FlattenSpace(env.ObservationSpace())
+		if err != nil {
+			return nil, fmt.Errorf("newFlattenObservation: could not flatten "+
+				"observation space: %v", err)
+		}
+	} else {
+		pyObservationSpace := newEnv.GetAttrString("observation_space")
+		defer pyObservationSpace.DecRef()
+		obsSpace, err = gogym.SpaceFromPyObject(pyObservationSpace)
+		if err != nil {
+			return nil, fmt.Errorf("newFlattenObservation: could not get "+
+				"Python observation space: %v", err)
+		}
 	}
 
 	// Create the new gogym Environment
@@ -79,7 +70,7 @@ func NewFlattenObservation(env gogym.Environment) (gogym.Environment, error) {
 		fmt.Sprintf("FlattenObservation(%v)", env.Name()),
 		env.ContinuousAction(),
 		env.ActionSpace(),
-		obsSpace.(*gogym.BoxSpace),
+		obsSpace,
 	)
 
 	return &FlattenObservation{
@@ -88,10 +79,11 @@ func NewFlattenObservation(env gogym.Environment) (gogym.Environment, error) {
 	}, nil
 }
 
-// Observation returns a flattened version of some observation x.
-// The argument x must be a valid argument to gogym.Flatten.
+// Observation returns a flattened version of some observation x from
+// the observation space of the wrapped environment. The argument x
+// must be a valid argument to gogym.Flatten.
 func (f *FlattenObservation) Observation(x interface{}) ([]float64, error) {
-	return gogym.Flatten(f.ObservationSpace(), x)
+	return gogym.Flatten(f.wrapped.ObservationSpace(), x)
 }
 
 // Close performs cleanup of environment resources
@@ -102,3 +94,9 @@ func (f *FlattenObservation) Close() {
 	// Close this environment
 	f.Environment.Close()
 }
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (f *FlattenObservation) Info() map[string]interface{} {
+	return gogym.Info(f.Environment)
+}
@@ -0,0 +1,115 @@
+package wrappers
+
+import (
+	"fmt"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// FrameStack wraps a gogym.Environment with a BoxSpace observation
+// space and stacks the last k observations along a new leading axis.
+// Observations are returned as vectors in row-major order, with the
+// oldest observation first. On reset, the starting state is repeated k
+// times.
+//
+// The stacked observation is a single vector rather than the
+// LazyFrames returned by the Python wrapper.
+//
+// https://github.com/openai/gym/blob/master/gym/wrappers/frame_stack.py
+type FrameStack struct {
+	gogym.Environment
+	observationSpace *gogym.BoxSpace
+	k                int
+
+	// frames is a ring buffer of the last k observations, where next
+	// is the index of the oldest observation
+	frames []*mat.VecDense
+	next   int
+}
+
+// NewFrameStack returns a new FrameStack which stacks the last k
+// observations of env
+func NewFrameStack(env gogym.Environment, k int) (gogym.Environment,
+	error) {
+	box, ok := env.ObservationSpace().(*gogym.BoxSpace)
+	if !ok {
+		return nil, fmt.Errorf("newFrameStack: observation space should be "+
+			"a BoxSpace but got %T", env.ObservationSpace())
+	}
+
+	space, err := box.Stack(k)
+	if err != nil {
+		return nil, fmt.Errorf("newFrameStack: %v", err)
+	}
+
+	return &FrameStack{
+		Environment:      env,
+		observationSpace: space,
+		k:                k,
+	}, nil
+}
+
+// ObservationSpace returns the stacked observation space
+func (f *FrameStack) ObservationSpace() gogym.Space {
+	return f.observationSpace
+}
+
+// Reset resets the environment and returns the starting state stacked
+// k times
+func (f *FrameStack) Reset() (*mat.VecDense, error) {
+	obs, err := f.Environment.Reset()
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+
+	f.frames = make([]*mat.VecDense, f.k)
+	for i := range f.frames {
+		f.frames[i] = obs
+	}
+	f.next = 0
+	return f.stacked(), nil
+}
+
+// Step takes one environmental step given some action a and returns
+// the last k observations stacked
+func (f *FrameStack) Step(a *mat.VecDense) (*mat.VecDense, float64, bool,
+	error) {
+	if f.frames == nil {
+		return nil, 0, false, fmt.Errorf("step: environment must be reset " +
+			"before stepping")
+	}
+
+	obs, reward, done, err := f.Environment.Step(a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+
+	f.frames[f.next] = obs
+	f.next = (f.next + 1) % f.k
+	return f.stacked(), reward, done, nil
+}
+
+// stacked returns the frames in the ring buffer stacked from oldest
+// to newest
+func (f *FrameStack) stacked() *mat.VecDense {
+	dim := f.frames[0].Len()
+	data := make([]float64, 0, dim*f.k)
+	for i := 0; i < f.k; i++ {
+		frame := f.frames[(f.next+i)%f.k]
+		data = append(data, frame.RawVector().Data...)
+	}
+	return mat.NewVecDense(len(data), data)
+}
+
+// Env returns nil, since a FrameStack has no Python environment
+func (f *FrameStack) Env() *python.PyObject {
+	return nil
+}
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (f *FrameStack) Info() map[string]interface{} {
+	return gogym.Info(f.Environment)
+}
@@ -0,0 +1,65 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewFrameStack(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	env, err := wrappers.NewFrameStack(goEnv, 3)
+	if err != nil {
+		t.Fatalf("newFrameStack: %v", err)
+	}
+
+	space := env.ObservationSpace().(*gogym.BoxSpace)
+	if shape := space.Shape(); len(shape) != 2 || shape[0] != 3 ||
+		shape[1] != 2 {
+		t.Errorf("observationSpace: want shape([3 2]) have(%v)", shape)
+	}
+
+	// The starting state is repeated on reset
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if want := []float64{0, 0, 0, 0, 0, 0}; !floats.Equal(
+		obs.RawVector().Data, want) {
+		t.Errorf("reset: want(%v) have(%v)", want, obs.RawVector().Data)
+	}
+
+	// Observations of goEnvironment are [t, sum of actions]
+	action := mat.NewVecDense(1, []float64{1})
+	wants := [][]float64{
+		{0, 0, 0, 0, 1, 1},
+		{0, 0, 1, 1, 2, 2},
+		{1, 1, 2, 2, 3, 3},
+		{2, 2, 3, 3, 4, 4},
+	}
+	for _, want := range wants {
+		obs, _, _, err = env.Step(action)
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		if !floats.Equal(obs.RawVector().Data, want) {
+			t.Errorf("step: want(%v) have(%v)", want, obs.RawVector().Data)
+		}
+		if !space.Contains(obs) {
+			t.Errorf("step: observation %v not in observation space",
+				obs.RawVector().Data)
+		}
+	}
+
+	if _, err = wrappers.NewFrameStack(goEnv, 0); err == nil {
+		t.Errorf("newFrameStack: expected error for k = 0")
+	}
+}
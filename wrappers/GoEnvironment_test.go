@@ -0,0 +1,101 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// goEnvironment is a deterministic gogym.Environment implemented in Go,
+// used to test wrappers without a Python gym environment. Observations
+// are 2-dimensional, with the first element being the current
+// timestep and the second element being the sum of the first elements
+// of all actions taken in the episode. A reward of 1 is given on each
+// step, and episodes end after episodeLength steps.
+type goEnvironment struct {
+	actionSpace      gogym.Space
+	observationSpace *gogym.BoxSpace
+	episodeLength    int
+
+	// infoFunc returns the info dictionary after t steps, including
+	// after Reset with t = 0
+	infoFunc func(t int) map[string]interface{}
+
+	t       int
+	sum     float64
+	info    map[string]interface{}
+	actions []*mat.VecDense // All actions taken
+	closed  int             // Number of calls to Close
+}
+
+// newGoEnvironment returns a new goEnvironment with the given action
+// space
+func newGoEnvironment(t *testing.T, actionSpace gogym.Space,
+	episodeLength int) *goEnvironment {
+	obsSpace, err := gogym.NewBox([]float64{0, -1000}, []float64{1000, 1000},
+		nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+
+	return &goEnvironment{
+		actionSpace:      actionSpace,
+		observationSpace: obsSpace,
+		episodeLength:    episodeLength,
+		info:             make(map[string]interface{}),
+	}
+}
+
+func (g *goEnvironment) Env() *python.PyObject { return nil }
+
+func (g *goEnvironment) Name() string { return "GoEnvironment" }
+
+func (g *goEnvironment) ContinuousAction() bool {
+	_, ok := g.actionSpace.(*gogym.BoxSpace)
+	return ok
+}
+
+func (g *goEnvironment) Seed(seed int) ([]int, error) {
+	return []int{seed}, nil
+}
+
+func (g *goEnvironment) ActionSpace() gogym.Space { return g.actionSpace }
+
+func (g *goEnvironment) ObservationSpace() gogym.Space {
+	return g.observationSpace
+}
+
+func (g *goEnvironment) Step(a *mat.VecDense) (*mat.VecDense, float64, bool,
+	error) {
+	action := mat.VecDenseCopyOf(a)
+	g.actions = append(g.actions, action)
+
+	g.t++
+	g.sum += action.AtVec(0)
+	if g.infoFunc != nil {
+		g.info = g.infoFunc(g.t)
+	}
+
+	return g.obs(), 1.0, g.t >= g.episodeLength, nil
+}
+
+func (g *goEnvironment) Reset() (*mat.VecDense, error) {
+	g.t = 0
+	g.sum = 0
+	g.info = make(map[string]interface{})
+	if g.infoFunc != nil {
+		g.info = g.infoFunc(g.t)
+	}
+	return g.obs(), nil
+}
+
+func (g *goEnvironment) Info() map[string]interface{} { return g.info }
+
+func (g *goEnvironment) Close() { g.closed++ }
+
+// obs returns the current observation
+func (g *goEnvironment) obs() *mat.VecDense {
+	return mat.NewVecDense(2, []float64{float64(g.t), g.sum})
+}
@@ -0,0 +1,94 @@
+package wrappers
+
+import (
+	"fmt"
+
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// MaskObservation wraps a gogym.Environment with a BoxSpace
+// observation space and removes fixed indices from each observation,
+// for example to remove the velocities from the observations of
+// CartPole. Observations of the wrapper are one-dimensional, with the
+// remaining elements in their original order.
+type MaskObservation struct {
+	*ObservationWrapper
+
+	// kept holds the indices of the observations which are kept
+	kept []int
+}
+
+// NewMaskObservation returns a new MaskObservation which removes the
+// elements at indices from the observations of env. Indices refer to
+// observations flattened in row-major order.
+func NewMaskObservation(env gogym.Environment,
+	indices []int) (gogym.Environment, error) {
+	box, ok := env.ObservationSpace().(*gogym.BoxSpace)
+	if !ok {
+		return nil, fmt.Errorf("newMaskObservation: observation space "+
+			"should be a BoxSpace but got %T", env.ObservationSpace())
+	}
+
+	dim := box.Low()[0].Len()
+	removed := make(map[int]bool, len(indices))
+	for _, i := range indices {
+		if i < 0 || i >= dim {
+			return nil, fmt.Errorf("newMaskObservation: index %v out of "+
+				"range for observations of length %v", i, dim)
+		}
+		removed[i] = true
+	}
+	if len(removed) == dim {
+		return nil, fmt.Errorf("newMaskObservation: cannot remove all " +
+			"elements of the observations")
+	}
+
+	// Create the observation space from the kept bounds
+	var kept []int
+	var low, high []float64
+	boxLow := box.Low()[0]
+	boxHigh := box.High()[0]
+	for i := 0; i < dim; i++ {
+		if !removed[i] {
+			kept = append(kept, i)
+			low = append(low, boxLow.AtVec(i))
+			high = append(high, boxHigh.AtVec(i))
+		}
+	}
+	space, err := gogym.NewTypedBox(low, high, nil, box.DType())
+	if err != nil {
+		return nil, fmt.Errorf("newMaskObservation: could not create "+
+			"observation space: %v", err)
+	}
+
+	m := &MaskObservation{kept: kept}
+	m.ObservationWrapper, err = NewObservationWrapper(env, space,
+		m.observation)
+	if err != nil {
+		return nil, fmt.Errorf("newMaskObservation: %v", err)
+	}
+	return m, nil
+}
+
+// observation returns obs with the masked elements removed
+func (m *MaskObservation) observation(obs *mat.VecDense) (*mat.VecDense,
+	error) {
+	masked := mat.NewVecDense(len(m.kept), nil)
+	for i, index := range m.kept {
+		if index >= obs.Len() {
+			return nil, fmt.Errorf("observation: observation of length %v "+
+				"too short", obs.Len())
+		}
+		masked.SetVec(i, obs.AtVec(index))
+	}
+	return masked, nil
+}
+
+// Kept returns the indices of the elements of the observations of the
+// wrapped environment which are kept
+func (m *MaskObservation) Kept() []int {
+	kept := make([]int, len(m.kept))
+	copy(kept, m.kept)
+	return kept
+}
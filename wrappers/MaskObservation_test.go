@@ -0,0 +1,72 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewMaskObservation(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	// Remove the sum of actions from the observations
+	env, err := wrappers.NewMaskObservation(goEnv, []int{1})
+	if err != nil {
+		t.Fatalf("newMaskObservation: %v", err)
+	}
+
+	space := env.ObservationSpace().(*gogym.BoxSpace)
+	if low, high := space.Low()[0], space.High()[0]; low.Len() != 1 ||
+		low.AtVec(0) != 0 || high.AtVec(0) != 1000 {
+		t.Errorf("observationSpace: want bounds([0] [1000]) have(%v %v)",
+			low.RawVector().Data, high.RawVector().Data)
+	}
+
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	obs, _, _, err := env.Step(mat.NewVecDense(1, []float64{1}))
+	if err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if obs.Len() != 1 || obs.AtVec(0) != 1 {
+		t.Errorf("step: want([1]) have(%v)", obs.RawVector().Data)
+	}
+
+	if _, err = wrappers.NewMaskObservation(goEnv, []int{2}); err == nil {
+		t.Errorf("newMaskObservation: expected error for index out of range")
+	}
+	if _, err = wrappers.NewMaskObservation(goEnv, []int{0, 1}); err == nil {
+		t.Errorf("newMaskObservation: expected error for removing all " +
+			"elements")
+	}
+}
+
+func TestMaskObservationCartPole(t *testing.T) {
+	env, err := gogym.Make("CartPole-v1")
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+	defer env.Close()
+
+	// Remove the cart and pole velocities
+	env, err = wrappers.NewMaskObservation(env, []int{1, 3})
+	if err != nil {
+		t.Fatalf("newMaskObservation: %v", err)
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if obs.Len() != 2 || !env.ObservationSpace().Contains(obs) {
+		t.Errorf("reset: observation %v not in observation space",
+			obs.RawVector().Data)
+	}
+}
@@ -0,0 +1,181 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// NormalizeObservation wraps a gogym.Environment with a BoxSpace
+// observation space and normalizes each observation using the running
+// mean and variance of all observations seen so far, such that
+// observations have approximately zero mean and unit variance. The
+// normalized observations may optionally be clipped to [-clip, clip].
+//
+// Updates to the running statistics can be frozen, for example during
+// evaluation, and the running statistics can be saved to and loaded
+// from disk, so that agents can be evaluated with the same
+// normalization they were trained with.
+//
+// https://github.com/openai/gym/blob/master/gym/wrappers/normalize.py
+type NormalizeObservation struct {
+	*ObservationWrapper
+	stats *RunningMeanStd
+
+	epsilon float64
+	clip    float64
+	frozen  bool
+}
+
+// NewNormalizeObservation returns a new NormalizeObservation wrapping
+// env. The argument epsilon is added to the variance before
+// normalizing for numerical stability. If clip is positive, then
+// normalized observations are clipped to [-clip, clip]. Otherwise, if
+// clip is 0 or math.Inf(1), then normalized observations are not
+// clipped.
+func NewNormalizeObservation(env gogym.Environment, epsilon,
+	clip float64) (gogym.Environment, error) {
+	box, ok := env.ObservationSpace().(*gogym.BoxSpace)
+	if !ok {
+		return nil, fmt.Errorf("newNormalizeObservation: observation space "+
+			"should be a BoxSpace but got %T", env.ObservationSpace())
+	}
+	if epsilon < 0 {
+		return nil, fmt.Errorf("newNormalizeObservation: epsilon must be "+
+			"non-negative, got %v", epsilon)
+	}
+	if clip < 0 {
+		return nil, fmt.Errorf("newNormalizeObservation: clip must be "+
+			"non-negative, got %v", clip)
+	}
+	if clip == 0 {
+		clip = math.Inf(1)
+	}
+
+	dim := box.Low()[0].Len()
+	stats, err := NewRunningMeanStd(dim)
+	if err != nil {
+		return nil, fmt.Errorf("newNormalizeObservation: %v", err)
+	}
+
+	// Normalized observations are in [-clip, clip]
+	low := make([]float64, dim)
+	high := make([]float64, dim)
+	for i := range low {
+		low[i] = -clip
+		high[i] = clip
+	}
+	space, err := gogym.NewBox(low, high, box.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("newNormalizeObservation: could not create "+
+			"observation space: %v", err)
+	}
+
+	n := &NormalizeObservation{
+		stats:   stats,
+		epsilon: epsilon,
+		clip:    clip,
+	}
+	n.ObservationWrapper, err = NewObservationWrapper(env, space,
+		n.observation)
+	if err != nil {
+		return nil, fmt.Errorf("newNormalizeObservation: %v", err)
+	}
+	return n, nil
+}
+
+// observation updates the running statistics with obs, unless frozen,
+// and returns obs normalized
+func (n *NormalizeObservation) observation(obs *mat.VecDense) (
+	*mat.VecDense, error) {
+	if !n.frozen {
+		if err := n.stats.Update(obs); err != nil {
+			return nil, fmt.Errorf("observation: %v", err)
+		}
+	}
+	return n.Normalize(obs)
+}
+
+// Normalize returns obs normalized and clipped using the current
+// running statistics, without updating them
+func (n *NormalizeObservation) Normalize(obs *mat.VecDense) (*mat.VecDense,
+	error) {
+	normalized, err := n.stats.Normalize(obs, n.epsilon)
+	if err != nil {
+		return nil, fmt.Errorf("normalize: %v", err)
+	}
+
+	for i := 0; i < normalized.Len(); i++ {
+		normalized.SetVec(i, math.Max(-n.clip,
+			math.Min(n.clip, normalized.AtVec(i))))
+	}
+	return normalized, nil
+}
+
+// UpdateBatch updates the running statistics with a batch of
+// observations, where each row of batch is a single observation, for
+// example from each environment in a set of vectorized environments
+func (n *NormalizeObservation) UpdateBatch(batch *mat.Dense) error {
+	if n.frozen {
+		return nil
+	}
+	if err := n.stats.UpdateBatch(batch); err != nil {
+		return fmt.Errorf("updateBatch: %v", err)
+	}
+	return nil
+}
+
+// Stats returns the running statistics of the observations
+func (n *NormalizeObservation) Stats() *RunningMeanStd {
+	return n.stats
+}
+
+// Freeze stops updates to the running statistics, for example during
+// evaluation. Observations are still normalized.
+func (n *NormalizeObservation) Freeze() {
+	n.frozen = true
+}
+
+// Unfreeze resumes updates to the running statistics
+func (n *NormalizeObservation) Unfreeze() {
+	n.frozen = false
+}
+
+// Frozen returns whether updates to the running statistics are frozen
+func (n *NormalizeObservation) Frozen() bool {
+	return n.frozen
+}
+
+// Save saves the running statistics to the file at path
+func (n *NormalizeObservation) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("save: %v", err)
+	}
+
+	if err := n.stats.Save(file); err != nil {
+		file.Close()
+		return fmt.Errorf("save: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("save: %v", err)
+	}
+	return nil
+}
+
+// Load loads running statistics saved by Save from the file at path
+func (n *NormalizeObservation) Load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("load: %v", err)
+	}
+	defer file.Close()
+
+	if err := n.stats.Load(file); err != nil {
+		return fmt.Errorf("load: %v", err)
+	}
+	return nil
+}
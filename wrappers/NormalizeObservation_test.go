@@ -0,0 +1,145 @@
+package wrappers_test
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewNormalizeObservation(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(3)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 1000)
+
+	env, err := wrappers.NewNormalizeObservation(goEnv, 1e-8, 5)
+	if err != nil {
+		t.Fatalf("newNormalizeObservation: %v", err)
+	}
+	normalize := env.(*wrappers.NormalizeObservation)
+
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	// Observations are [t, sum of actions] for t = 0, ..., 100
+	timesteps := []float64{0}
+	action := mat.NewVecDense(1, []float64{2})
+	for i := 1; i <= 100; i++ {
+		obs, _, _, err := env.Step(action)
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		if !env.ObservationSpace().Contains(obs) {
+			t.Errorf("step: observation %v not in observation space",
+				obs.RawVector().Data)
+		}
+		timesteps = append(timesteps, float64(i))
+	}
+
+	mean := floats.Sum(timesteps) / float64(len(timesteps))
+	var variance float64
+	for _, x := range timesteps {
+		variance += (x - mean) * (x - mean)
+	}
+	variance /= float64(len(timesteps))
+
+	stats := normalize.Stats()
+	if math.Abs(stats.Mean().AtVec(0)-mean) > 1e-3 {
+		t.Errorf("mean: want(%v) have(%v)", mean, stats.Mean().AtVec(0))
+	}
+	if math.Abs(stats.Var().AtVec(0)-variance) > 1e-1 {
+		t.Errorf("var: want(%v) have(%v)", variance, stats.Var().AtVec(0))
+	}
+
+	// Frozen statistics are not updated
+	normalize.Freeze()
+	count := stats.Count()
+	if _, _, _, err = env.Step(action); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if stats.Count() != count {
+		t.Errorf("freeze: statistics updated while frozen")
+	}
+
+	// Observations far from the mean are clipped
+	clipped, err := normalize.Normalize(mat.NewVecDense(2,
+		[]float64{1e6, -1e6}))
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if clipped.AtVec(0) != 5 || clipped.AtVec(1) != -5 {
+		t.Errorf("normalize: want([5 -5]) have(%v)",
+			clipped.RawVector().Data)
+	}
+
+	// Saved statistics can be loaded into a new wrapper
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err = normalize.Save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	loadedEnv, err := wrappers.NewNormalizeObservation(goEnv, 1e-8, 5)
+	if err != nil {
+		t.Fatalf("newNormalizeObservation: %v", err)
+	}
+	loaded := loadedEnv.(*wrappers.NormalizeObservation)
+	if err = loaded.Load(path); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !mat.Equal(loaded.Stats().Mean(), stats.Mean()) ||
+		!mat.Equal(loaded.Stats().Var(), stats.Var()) ||
+		loaded.Stats().Count() != stats.Count() {
+		t.Errorf("load: loaded statistics differ from saved statistics")
+	}
+
+	if _, err = wrappers.NewNormalizeObservation(newGoEnvironment(t,
+		actionSpace, 10), 1e-8, -1); err == nil {
+		t.Errorf("newNormalizeObservation: expected error for negative clip")
+	}
+}
+
+func TestRunningMeanStdUpdateBatch(t *testing.T) {
+	batch := mat.NewDense(4, 2, []float64{
+		1, 10,
+		2, 20,
+		3, 30,
+		4, 40,
+	})
+
+	// Updating with a batch is equivalent to updating with each row
+	batchStats, err := wrappers.NewRunningMeanStd(2)
+	if err != nil {
+		t.Fatalf("newRunningMeanStd: %v", err)
+	}
+	if err = batchStats.UpdateBatch(batch); err != nil {
+		t.Fatalf("updateBatch: %v", err)
+	}
+
+	rowStats, err := wrappers.NewRunningMeanStd(2)
+	if err != nil {
+		t.Fatalf("newRunningMeanStd: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if err = rowStats.Update(mat.VecDenseCopyOf(batch.RowView(i))); err !=
+			nil {
+			t.Fatalf("update: %v", err)
+		}
+	}
+
+	if !mat.EqualApprox(batchStats.Mean(), rowStats.Mean(), 1e-9) {
+		t.Errorf("updateBatch: mean want(%v) have(%v)",
+			rowStats.Mean().RawVector().Data,
+			batchStats.Mean().RawVector().Data)
+	}
+	if !mat.EqualApprox(batchStats.Var(), rowStats.Var(), 1e-9) {
+		t.Errorf("updateBatch: var want(%v) have(%v)",
+			rowStats.Var().RawVector().Data,
+			batchStats.Var().RawVector().Data)
+	}
+}
@@ -0,0 +1,103 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// NormalizeReward wraps a gogym.Environment and scales each reward by
+// the running standard deviation of the discounted return, such that
+// the discounted return has approximately unit variance. The
+// discounted return is reset to 0 at the end of each episode and
+// whenever the environment is reset.
+//
+// https://github.com/openai/gym/blob/master/gym/wrappers/normalize.py
+type NormalizeReward struct {
+	gogym.Environment
+	stats *RunningMeanStd
+
+	gamma   float64
+	epsilon float64
+
+	// Discounted return of the current episode
+	returns float64
+}
+
+// NewNormalizeReward returns a new NormalizeReward wrapping env, where
+// gamma is the discount factor of the discounted return, and epsilon
+// is added to the variance before scaling for numerical stability
+func NewNormalizeReward(env gogym.Environment, gamma,
+	epsilon float64) (gogym.Environment, error) {
+	if gamma < 0 || gamma > 1 {
+		return nil, fmt.Errorf("newNormalizeReward: gamma must be in "+
+			"[0, 1], got %v", gamma)
+	}
+	if epsilon < 0 {
+		return nil, fmt.Errorf("newNormalizeReward: epsilon must be "+
+			"non-negative, got %v", epsilon)
+	}
+
+	stats, err := NewRunningMeanStd(1)
+	if err != nil {
+		return nil, fmt.Errorf("newNormalizeReward: %v", err)
+	}
+
+	return &NormalizeReward{
+		Environment: env,
+		stats:       stats,
+		gamma:       gamma,
+		epsilon:     epsilon,
+	}, nil
+}
+
+// Stats returns the running statistics of the discounted return
+func (n *NormalizeReward) Stats() *RunningMeanStd {
+	return n.stats
+}
+
+// Reset resets the environment and the discounted return
+func (n *NormalizeReward) Reset() (*mat.VecDense, error) {
+	obs, err := n.Environment.Reset()
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+	n.returns = 0
+	return obs, nil
+}
+
+// Step takes one environmental step given some action a, scaling the
+// reward by the running standard deviation of the discounted return
+func (n *NormalizeReward) Step(a *mat.VecDense) (*mat.VecDense, float64,
+	bool, error) {
+	obs, reward, done, err := n.Environment.Step(a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+
+	n.returns = n.returns*n.gamma + reward
+	err = n.stats.Update(mat.NewVecDense(1, []float64{n.returns}))
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+	if done {
+		n.returns = 0
+	}
+
+	reward /= math.Sqrt(n.stats.variance.AtVec(0) + n.epsilon)
+	return obs, reward, done, nil
+}
+
+// Env returns nil, since a NormalizeReward has no Python environment
+func (n *NormalizeReward) Env() *python.PyObject {
+	return nil
+}
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (n *NormalizeReward) Info() map[string]interface{} {
+	return gogym.Info(n.Environment)
+}
@@ -0,0 +1,58 @@
+package wrappers_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewNormalizeReward(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 3)
+
+	gamma := 0.5
+	env, err := wrappers.NewNormalizeReward(goEnv, gamma, 0)
+	if err != nil {
+		t.Fatalf("newNormalizeReward: %v", err)
+	}
+
+	// Track the statistics of the discounted return separately
+	stats, err := wrappers.NewRunningMeanStd(1)
+	if err != nil {
+		t.Fatalf("newRunningMeanStd: %v", err)
+	}
+
+	action := mat.NewVecDense(1, []float64{0})
+	for episode := 0; episode < 3; episode++ {
+		if _, err = env.Reset(); err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+
+		// goEnvironment gives a reward of 1 on each step
+		returns := 0.0
+		for done := false; !done; {
+			var reward float64
+			_, reward, done, err = env.Step(action)
+			if err != nil {
+				t.Fatalf("step: %v", err)
+			}
+
+			returns = returns*gamma + 1
+			stats.Update(mat.NewVecDense(1, []float64{returns}))
+			want := 1 / math.Sqrt(stats.Var().AtVec(0))
+			if math.Abs(reward-want) > 1e-9 {
+				t.Errorf("step: want reward(%v) have(%v)", want, reward)
+			}
+		}
+	}
+
+	if _, err = wrappers.NewNormalizeReward(goEnv, 1.5, 0); err == nil {
+		t.Errorf("newNormalizeReward: expected error for gamma > 1")
+	}
+}
@@ -0,0 +1,78 @@
+package wrappers
+
+import (
+	"fmt"
+
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ObservationDelay wraps a gogym.Environment and delays each
+// observation by a fixed number of steps using a queue, such that each
+// step returns the observation of the wrapped environment from delay
+// steps earlier. At the start of each episode, the queue is filled
+// with the starting state.
+type ObservationDelay struct {
+	*ObservationWrapper
+	delay int
+
+	// queue holds the observations which have not yet been returned,
+	// from oldest to newest
+	queue []*mat.VecDense
+}
+
+// NewObservationDelay returns a new ObservationDelay which delays the
+// observations of env by delay steps
+func NewObservationDelay(env gogym.Environment,
+	delay int) (gogym.Environment, error) {
+	if delay <= 0 {
+		return nil, fmt.Errorf("newObservationDelay: delay must be "+
+			"positive, got %v", delay)
+	}
+
+	d := &ObservationDelay{delay: delay}
+
+	var err error
+	d.ObservationWrapper, err = NewObservationWrapper(env, nil,
+		d.observation)
+	if err != nil {
+		return nil, fmt.Errorf("newObservationDelay: %v", err)
+	}
+	return d, nil
+}
+
+// observation adds obs to the queue and returns the oldest observation
+// in the queue
+func (d *ObservationDelay) observation(obs *mat.VecDense) (*mat.VecDense,
+	error) {
+	d.queue = append(d.queue, obs)
+	next := d.queue[0]
+	d.queue = d.queue[1:]
+	return next, nil
+}
+
+// Reset resets the environment, fills the queue with the starting
+// state, and returns the starting state
+func (d *ObservationDelay) Reset() (*mat.VecDense, error) {
+	obs, err := d.Environment.Reset()
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+
+	d.queue = make([]*mat.VecDense, d.delay)
+	for i := range d.queue {
+		d.queue[i] = obs
+	}
+	return obs, nil
+}
+
+// Step takes one environmental step given some action a and returns
+// the observation from delay steps earlier
+func (d *ObservationDelay) Step(a *mat.VecDense) (*mat.VecDense, float64,
+	bool, error) {
+	if d.queue == nil {
+		return nil, 0, false, fmt.Errorf("step: environment must be reset " +
+			"before stepping")
+	}
+	return d.ObservationWrapper.Step(a)
+}
@@ -0,0 +1,52 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewObservationDelay(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	env, err := wrappers.NewObservationDelay(goEnv, 2)
+	if err != nil {
+		t.Fatalf("newObservationDelay: %v", err)
+	}
+	if env.ObservationSpace() != goEnv.ObservationSpace() {
+		t.Errorf("observationSpace: wrapped observation space not kept")
+	}
+
+	// Observations of goEnvironment are [t, sum of actions]
+	for episode := 0; episode < 2; episode++ {
+		obs, err := env.Reset()
+		if err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+		if obs.AtVec(0) != 0 {
+			t.Errorf("reset: want timestep(0) have(%v)", obs.AtVec(0))
+		}
+
+		want := []float64{0, 0, 1, 2, 3}
+		for i := range want {
+			obs, _, _, err = env.Step(mat.NewVecDense(1, []float64{0}))
+			if err != nil {
+				t.Fatalf("step: %v", err)
+			}
+			if obs.AtVec(0) != want[i] {
+				t.Errorf("step: want timestep(%v) have(%v)", want[i],
+					obs.AtVec(0))
+			}
+		}
+	}
+
+	if _, err = wrappers.NewObservationDelay(goEnv, 0); err == nil {
+		t.Errorf("newObservationDelay: expected error for delay 0")
+	}
+}
@@ -0,0 +1,114 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gogym"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ObservationDropout wraps a gogym.Environment with a BoxSpace
+// observation space and randomly drops observations by setting them
+// to 0. Either each feature of an observation is dropped independently,
+// or whole observations are dropped. The observation space of the
+// wrapper is extended to include 0 if needed.
+type ObservationDropout struct {
+	*ObservationWrapper
+	rng *rand.Rand
+	p   float64
+
+	// features is true if features are dropped independently, and
+	// false if whole observations are dropped
+	features bool
+}
+
+// NewObservationDropout returns a new ObservationDropout which sets
+// each whole observation of env to 0 with probability p
+func NewObservationDropout(env gogym.Environment,
+	p float64) (gogym.Environment, error) {
+	d, err := newObservationDropout(env, p, false)
+	if err != nil {
+		return nil, fmt.Errorf("newObservationDropout: %v", err)
+	}
+	return d, nil
+}
+
+// NewFeatureDropout returns a new ObservationDropout which sets each
+// feature of each observation of env to 0 independently with
+// probability p
+func NewFeatureDropout(env gogym.Environment, p float64) (gogym.Environment,
+	error) {
+	d, err := newObservationDropout(env, p, true)
+	if err != nil {
+		return nil, fmt.Errorf("newFeatureDropout: %v", err)
+	}
+	return d, nil
+}
+
+// newObservationDropout returns a new ObservationDropout
+func newObservationDropout(env gogym.Environment, p float64,
+	features bool) (*ObservationDropout, error) {
+	box, ok := env.ObservationSpace().(*gogym.BoxSpace)
+	if !ok {
+		return nil, fmt.Errorf("observation space should be a BoxSpace but "+
+			"got %T", env.ObservationSpace())
+	}
+	if p < 0 || p > 1 {
+		return nil, fmt.Errorf("p must be in [0, 1], got %v", p)
+	}
+
+	// Dropped observations are 0
+	low := mat.VecDenseCopyOf(box.Low()[0]).RawVector().Data
+	high := mat.VecDenseCopyOf(box.High()[0]).RawVector().Data
+	for i := range low {
+		low[i] = math.Min(low[i], 0)
+		high[i] = math.Max(high[i], 0)
+	}
+	space, err := gogym.NewTypedBox(low, high, box.Shape(), box.DType())
+	if err != nil {
+		return nil, fmt.Errorf("could not create observation space: %v", err)
+	}
+
+	src := rand.NewSource(gogym.NewSeed())
+	d := &ObservationDropout{
+		rng:      rand.New(src),
+		p:        p,
+		features: features,
+	}
+
+	d.ObservationWrapper, err = NewObservationWrapper(env, space,
+		d.observation)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// observation returns obs with features or the whole observation
+// dropped
+func (d *ObservationDropout) observation(obs *mat.VecDense) (*mat.VecDense,
+	error) {
+	dropped := mat.VecDenseCopyOf(obs)
+	if !d.features {
+		if d.rng.Float64() < d.p {
+			dropped.Zero()
+		}
+		return dropped, nil
+	}
+
+	for i := 0; i < dropped.Len(); i++ {
+		if d.rng.Float64() < d.p {
+			dropped.SetVec(i, 0)
+		}
+	}
+	return dropped, nil
+}
+
+// Seed seeds the wrapped environment and the random number generator
+// which determines which observations are dropped
+func (d *ObservationDropout) Seed(seed int) ([]int, error) {
+	d.rng.Seed(uint64(seed))
+	return d.Environment.Seed(seed)
+}
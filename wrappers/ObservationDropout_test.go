@@ -0,0 +1,72 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewObservationDropout(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	action := mat.NewVecDense(1, []float64{1})
+
+	for _, features := range []bool{false, true} {
+		goEnv := newGoEnvironment(t, actionSpace, 1000)
+		var env gogym.Environment
+		if features {
+			env, err = wrappers.NewFeatureDropout(goEnv, 0.5)
+		} else {
+			env, err = wrappers.NewObservationDropout(goEnv, 0.5)
+		}
+		if err != nil {
+			t.Fatalf("newObservationDropout: %v", err)
+		}
+		env.Seed(1)
+
+		if _, err = env.Reset(); err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+
+		// Observations of goEnvironment are [t, t] with action 1
+		partial, dropped := 0, 0
+		for i := 1; i <= 1000; i++ {
+			obs, _, _, err := env.Step(action)
+			if err != nil {
+				t.Fatalf("step: %v", err)
+			}
+			if !env.ObservationSpace().Contains(obs) {
+				t.Errorf("step: observation %v not in observation space",
+					obs.RawVector().Data)
+			}
+
+			for j := 0; j < 2; j++ {
+				if obs.AtVec(j) != 0 && obs.AtVec(j) != float64(i) {
+					t.Fatalf("step: observation %v is not dropped or "+
+						"original", obs.RawVector().Data)
+				}
+			}
+			switch {
+			case obs.AtVec(0) == 0 && obs.AtVec(1) == 0:
+				dropped++
+			case obs.AtVec(0) == 0 || obs.AtVec(1) == 0:
+				partial++
+			}
+		}
+
+		if features && partial == 0 {
+			t.Errorf("newFeatureDropout: no features dropped independently")
+		}
+		if !features && partial != 0 {
+			t.Errorf("newObservationDropout: features dropped independently")
+		}
+		if dropped < 100 || dropped > 600 {
+			t.Errorf("step: unexpected number of dropped observations %v",
+				dropped)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gogym"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ObservationNoise wraps a gogym.Environment with a BoxSpace
+// observation space and adds Gaussian noise to each observation. Since
+// Gaussian noise is unbounded, the observation space of the wrapper is
+// unbounded, unless the standard deviation of the noise is 0.
+type ObservationNoise struct {
+	*ObservationWrapper
+	rng    *rand.Rand
+	stddev float64
+}
+
+// NewObservationNoise returns a new ObservationNoise which adds
+// Gaussian noise with mean 0 and standard deviation stddev to each
+// element of the observations of env
+func NewObservationNoise(env gogym.Environment,
+	stddev float64) (gogym.Environment, error) {
+	box, ok := env.ObservationSpace().(*gogym.BoxSpace)
+	if !ok {
+		return nil, fmt.Errorf("newObservationNoise: observation space "+
+			"should be a BoxSpace but got %T", env.ObservationSpace())
+	}
+	if stddev < 0 {
+		return nil, fmt.Errorf("newObservationNoise: stddev must be "+
+			"non-negative, got %v", stddev)
+	}
+
+	// Noisy observations are unbounded
+	space := box
+	if stddev > 0 {
+		dim := box.Low()[0].Len()
+		low := make([]float64, dim)
+		high := make([]float64, dim)
+		for i := range low {
+			low[i] = math.Inf(-1)
+			high[i] = math.Inf(1)
+		}
+
+		var err error
+		space, err = gogym.NewBox(low, high, box.Shape())
+		if err != nil {
+			return nil, fmt.Errorf("newObservationNoise: could not create "+
+				"observation space: %v", err)
+		}
+	}
+
+	src := rand.NewSource(gogym.NewSeed())
+	n := &ObservationNoise{
+		rng:    rand.New(src),
+		stddev: stddev,
+	}
+
+	var err error
+	n.ObservationWrapper, err = NewObservationWrapper(env, space,
+		n.observation)
+	if err != nil {
+		return nil, fmt.Errorf("newObservationNoise: %v", err)
+	}
+	return n, nil
+}
+
+// observation returns obs with Gaussian noise added
+func (n *ObservationNoise) observation(obs *mat.VecDense) (*mat.VecDense,
+	error) {
+	noisy := mat.NewVecDense(obs.Len(), nil)
+	for i := 0; i < obs.Len(); i++ {
+		noisy.SetVec(i, obs.AtVec(i)+n.rng.NormFloat64()*n.stddev)
+	}
+	return noisy, nil
+}
+
+// Seed seeds the wrapped environment and the random number generator
+// of the noise
+func (n *ObservationNoise) Seed(seed int) ([]int, error) {
+	n.rng.Seed(uint64(seed))
+	return n.Environment.Seed(seed)
+}
@@ -0,0 +1,79 @@
+package wrappers_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewObservationNoise(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+
+	// noisyObservations returns the first element of each observation
+	// in an episode, seeding the wrapper with seed if it is non-negative
+	noisyObservations := func(seed int) []float64 {
+		env, err := wrappers.NewObservationNoise(newGoEnvironment(t,
+			actionSpace, 500), 0.1)
+		if err != nil {
+			t.Fatalf("newObservationNoise: %v", err)
+		}
+		if seed >= 0 {
+			env.Seed(seed)
+		}
+
+		obs, err := env.Reset()
+		if err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+		observations := []float64{obs.AtVec(0)}
+		for done := false; !done; {
+			obs, _, done, err = env.Step(mat.NewVecDense(1, []float64{0}))
+			if err != nil {
+				t.Fatalf("step: %v", err)
+			}
+			if !env.ObservationSpace().Contains(obs) {
+				t.Errorf("step: observation %v not in observation space",
+					obs.RawVector().Data)
+			}
+			observations = append(observations, obs.AtVec(0))
+		}
+		return observations
+	}
+
+	// Observations of goEnvironment are [t, sum of actions]
+	observations := noisyObservations(1)
+	var mean, sqErr float64
+	for i, obs := range observations {
+		mean += obs - float64(i)
+		sqErr += (obs - float64(i)) * (obs - float64(i))
+	}
+	mean /= float64(len(observations))
+	stddev := math.Sqrt(sqErr / float64(len(observations)))
+	if math.Abs(mean) > 0.05 || math.Abs(stddev-0.1) > 0.05 {
+		t.Errorf("step: want noise with mean(0) stddev(0.1) have(%v) (%v)",
+			mean, stddev)
+	}
+
+	again := noisyObservations(1)
+	for i := range observations {
+		if observations[i] != again[i] {
+			t.Fatalf("seed: noisy observations not reproducible")
+		}
+	}
+
+	gogym.SetSeed(7)
+	observations = noisyObservations(-1)
+	gogym.SetSeed(7)
+	again = noisyObservations(-1)
+	for i := range observations {
+		if observations[i] != again[i] {
+			t.Fatalf("setSeed: noisy observations not reproducible")
+		}
+	}
+}
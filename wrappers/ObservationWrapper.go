@@ -0,0 +1,107 @@
+package wrappers
+
+import (
+	"fmt"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ObservationFunc transforms an observation of a wrapped environment
+// into an observation of the wrapper
+type ObservationFunc func(obs *mat.VecDense) (*mat.VecDense, error)
+
+// ObservationWrapper wraps a gogym.Environment and transforms each
+// observation returned by Step and Reset using an ObservationFunc.
+// It is the Go equivalent of gym.ObservationWrapper.
+//
+// New observation wrappers are implemented by passing their
+// transformation to NewObservationWrapper. A type may embed the
+// returned *ObservationWrapper to add state or methods, as
+// OneHotObservation does, but defining an Observation method on the
+// embedding type has no effect, since Step and Reset of the
+// ObservationWrapper always call the ObservationFunc.
+//
+// https://github.com/openai/gym/blob/master/gym/core.py
+type ObservationWrapper struct {
+	gogym.Environment
+	observationSpace gogym.Space
+	observation      ObservationFunc
+}
+
+// NewObservationWrapper returns a new ObservationWrapper which
+// transforms the observations of env using f. The argument space is
+// the observation space of the wrapper, which should contain each
+// observation returned by f. If space is nil, then the observation
+// space of env is used.
+func NewObservationWrapper(env gogym.Environment, space gogym.Space,
+	f ObservationFunc) (*ObservationWrapper, error) {
+	if f == nil {
+		return nil, fmt.Errorf("newObservationWrapper: observation " +
+			"function cannot be nil")
+	}
+	if space == nil {
+		space = env.ObservationSpace()
+	}
+
+	return &ObservationWrapper{
+		Environment:      env,
+		observationSpace: space,
+		observation:      f,
+	}, nil
+}
+
+// ObservationSpace returns the observation space of the wrapper
+func (w *ObservationWrapper) ObservationSpace() gogym.Space {
+	return w.observationSpace
+}
+
+// Observation returns the observation of the wrapper corresponding to
+// observation obs of the wrapped environment
+func (w *ObservationWrapper) Observation(obs *mat.VecDense) (*mat.VecDense,
+	error) {
+	return w.observation(obs)
+}
+
+// Step takes one environmental step given some action a, transforming
+// the next observation
+func (w *ObservationWrapper) Step(a *mat.VecDense) (*mat.VecDense, float64,
+	bool, error) {
+	obs, reward, done, err := w.Environment.Step(a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+
+	obs, err = w.Observation(obs)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+	return obs, reward, done, nil
+}
+
+// Reset resets the environment and returns the transformed starting
+// state
+func (w *ObservationWrapper) Reset() (*mat.VecDense, error) {
+	obs, err := w.Environment.Reset()
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+
+	obs, err = w.Observation(obs)
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+	return obs, nil
+}
+
+// Env returns nil, since an ObservationWrapper has no Python environment
+func (w *ObservationWrapper) Env() *python.PyObject {
+	return nil
+}
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (w *ObservationWrapper) Info() map[string]interface{} {
+	return gogym.Info(w.Environment)
+}
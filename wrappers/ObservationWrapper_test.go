@@ -0,0 +1,75 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewObservationWrapper(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	// Keep only the timestep of each observation
+	space, err := gogym.NewBox([]float64{0}, []float64{1000}, nil)
+	if err != nil {
+		t.Fatalf("newBox: %v", err)
+	}
+	env, err := wrappers.NewObservationWrapper(goEnv, space,
+		func(obs *mat.VecDense) (*mat.VecDense, error) {
+			return mat.NewVecDense(1, []float64{obs.AtVec(0)}), nil
+		})
+	if err != nil {
+		t.Fatalf("newObservationWrapper: %v", err)
+	}
+	if env.ObservationSpace() != space {
+		t.Errorf("observationSpace: want(%v) have(%v)", space,
+			env.ObservationSpace())
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if obs.Len() != 1 || obs.AtVec(0) != 0 {
+		t.Errorf("reset: want([0]) have(%v)", obs.RawVector().Data)
+	}
+
+	obs, _, _, err = env.Step(mat.NewVecDense(1, []float64{1}))
+	if err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if !space.Contains(obs) || obs.AtVec(0) != 1 {
+		t.Errorf("step: want([1]) have(%v)", obs.RawVector().Data)
+	}
+
+	// The info dictionary of the wrapped environment is forwarded
+	goEnv.infoFunc = func(t int) map[string]interface{} {
+		return map[string]interface{}{"t": t}
+	}
+	if _, _, _, err = env.Step(mat.NewVecDense(1, []float64{1})); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if info := gogym.Info(env); info["t"] != 2 {
+		t.Errorf("info: want(map[t:2]) have(%v)", info)
+	}
+
+	// A nil space keeps the wrapped observation space
+	env, err = wrappers.NewObservationWrapper(goEnv, nil,
+		func(obs *mat.VecDense) (*mat.VecDense, error) { return obs, nil })
+	if err != nil {
+		t.Fatalf("newObservationWrapper: %v", err)
+	}
+	if env.ObservationSpace() != goEnv.ObservationSpace() {
+		t.Errorf("observationSpace: wrapped observation space not kept")
+	}
+
+	if _, err = wrappers.NewObservationWrapper(goEnv, nil, nil); err == nil {
+		t.Errorf("newObservationWrapper: expected error for nil function")
+	}
+}
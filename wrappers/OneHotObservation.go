@@ -0,0 +1,97 @@
+package wrappers
+
+import (
+	"fmt"
+
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// OneHotObservation wraps a gogym.Environment with discrete
+// observations and one-hot encodes each observation using
+// gogym.Flatten. The observation space of the wrapped environment must
+// be a DiscreteSpace, or a TupleSpace or DictSpace composed only of
+// DiscreteSpaces, such as the observation space of Blackjack. In the
+// latter case, each DiscreteSpace is one-hot encoded separately and
+// the encodings are concatenated in the order of gogym.Flatten.
+//
+// The observation space of the wrapper is the BoxSpace returned by
+// gogym.FlattenSpace, which has one element per discrete value and
+// bounds [0, 1].
+type OneHotObservation struct {
+	*ObservationWrapper
+	wrappedSpace gogym.Space
+}
+
+// NewOneHotObservation returns a new OneHotObservation which one-hot
+// encodes the observations of env
+func NewOneHotObservation(env gogym.Environment) (gogym.Environment,
+	error) {
+	wrappedSpace := env.ObservationSpace()
+	if err := checkDiscrete(wrappedSpace); err != nil {
+		return nil, fmt.Errorf("newOneHotObservation: %v", err)
+	}
+
+	space, err := gogym.FlattenSpace(wrappedSpace)
+	if err != nil {
+		return nil, fmt.Errorf("newOneHotObservation: could not create "+
+			"observation space: %v", err)
+	}
+
+	o := &OneHotObservation{wrappedSpace: wrappedSpace}
+	o.ObservationWrapper, err = NewObservationWrapper(env, space,
+		o.observation)
+	if err != nil {
+		return nil, fmt.Errorf("newOneHotObservation: %v", err)
+	}
+	return o, nil
+}
+
+// observation returns the one-hot encoding of obs. Since observations
+// of composite spaces are concatenated by gogym.PointToVec, obs is
+// first converted back to a point in the wrapped observation space.
+func (o *OneHotObservation) observation(obs *mat.VecDense) (*mat.VecDense,
+	error) {
+	point, err := gogym.PointFromVec(o.wrappedSpace, obs)
+	if err != nil {
+		return nil, fmt.Errorf("observation: %v", err)
+	}
+
+	onehot, err := gogym.Flatten(o.wrappedSpace, point)
+	if err != nil {
+		return nil, fmt.Errorf("observation: %v", err)
+	}
+	return mat.NewVecDense(len(onehot), onehot), nil
+}
+
+// checkDiscrete returns an error if space is not a DiscreteSpace or a
+// TupleSpace or DictSpace composed only of DiscreteSpaces
+func checkDiscrete(space gogym.Space) error {
+	switch s := space.(type) {
+	case *gogym.DiscreteSpace:
+		return nil
+
+	case *gogym.TupleSpace:
+		for i := 0; i < s.Len(); i++ {
+			if err := checkDiscrete(s.At(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *gogym.DictSpace:
+		for _, key := range s.Keys() {
+			value, err := s.At(key)
+			if err != nil {
+				return err
+			}
+			if err := checkDiscrete(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("observation space should be composed of "+
+		"DiscreteSpaces but got %T", space)
+}
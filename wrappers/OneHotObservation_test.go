@@ -0,0 +1,120 @@
+package wrappers_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewOneHotObservation(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	// Observations are the timestep modulo 3 starting at 1, and the sum
+	// of actions modulo 2
+	timestep, err := gogym.NewDiscreteStart(3, 1)
+	if err != nil {
+		t.Fatalf("newDiscreteStart: %v", err)
+	}
+	parity, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	tuple, err := gogym.NewTuple(timestep, parity)
+	if err != nil {
+		t.Fatalf("newTuple: %v", err)
+	}
+	discreteEnv, err := wrappers.NewObservationWrapper(goEnv, tuple,
+		func(obs *mat.VecDense) (*mat.VecDense, error) {
+			return mat.NewVecDense(2, []float64{
+				math.Mod(obs.AtVec(0), 3) + 1,
+				math.Mod(obs.AtVec(1), 2),
+			}), nil
+		})
+	if err != nil {
+		t.Fatalf("newObservationWrapper: %v", err)
+	}
+
+	env, err := wrappers.NewOneHotObservation(discreteEnv)
+	if err != nil {
+		t.Fatalf("newOneHotObservation: %v", err)
+	}
+	space, ok := env.ObservationSpace().(*gogym.BoxSpace)
+	if !ok || space.Low()[0].Len() != 5 || floats.Max(
+		space.High()[0].RawVector().Data) != 1 {
+		t.Fatalf("observationSpace: want Box of size 5 with bounds [0, 1] "+
+			"have(%v)", env.ObservationSpace())
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if want := []float64{1, 0, 0, 1, 0}; !floats.Equal(obs.RawVector().Data,
+		want) {
+		t.Errorf("reset: want(%v) have(%v)", want, obs.RawVector().Data)
+	}
+
+	want := [][]float64{
+		{0, 1, 0, 0, 1},
+		{0, 0, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+	}
+	for i := range want {
+		obs, _, _, err = env.Step(mat.NewVecDense(1, []float64{1}))
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		if !floats.Equal(obs.RawVector().Data, want[i]) {
+			t.Errorf("step: want(%v) have(%v)", want[i], obs.RawVector().Data)
+		}
+		if !space.Contains(obs) {
+			t.Errorf("step: observation %v not in observation space",
+				obs.RawVector().Data)
+		}
+	}
+
+	if _, err = wrappers.NewOneHotObservation(goEnv); err == nil {
+		t.Errorf("newOneHotObservation: expected error for BoxSpace " +
+			"observation space")
+	}
+}
+
+func TestOneHotObservationBlackjack(t *testing.T) {
+	env, err := gogym.Make("Blackjack-v0")
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+	defer env.Close()
+
+	dim, err := gogym.FlatDim(env.ObservationSpace())
+	if err != nil {
+		t.Fatalf("flatDim: %v", err)
+	}
+
+	env, err = wrappers.NewOneHotObservation(env)
+	if err != nil {
+		t.Fatalf("newOneHotObservation: %v", err)
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if obs.Len() != dim || !env.ObservationSpace().Contains(obs) {
+		t.Errorf("reset: observation %v not in observation space",
+			obs.RawVector().Data)
+	}
+
+	// One element is hot for each of the three discrete spaces
+	if sum := floats.Sum(obs.RawVector().Data); sum != 3 {
+		t.Errorf("reset: want 3 hot elements have(%v)", sum)
+	}
+}
@@ -7,27 +7,9 @@ import (
 	"github.com/samuelfneumann/gogym"
 )
 
-// OpenAI Gym wrappers module
+// gym.wrappers.pixel_observation Python module, imported by importModule
 var pixelModule *python.PyObject
 
-// init performs setup before running
-func init() {
-	wrappersModule := python.PyImport_ImportModule("gym.wrappers.pixel_observation")
-	if wrappersModule == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		panic("init: could not import gym.wrappers.pixel_observation")
-	}
-	defer wrappersModule.DecRef()
-	pixelModule = python.PyImport_AddModule("gym.wrappers.pixel_observation")
-	wrappersModule.IncRef()
-}
-
 // PixelObservation wraps a gogym.Environment to provide pixel
 // observations.
 //
@@ -42,6 +24,11 @@ type PixelObservation struct {
 // observations.
 func NewPixelObservation(env *gogym.GymEnv, pixelsOnly bool,
 	pixelKeys string) (gogym.Environment, error) {
+	if err := importModule(&pixelModule,
+		"gym.wrappers.pixel_observation"); err != nil {
+		return nil, fmt.Errorf("pixelObservations: %v", err)
+	}
+
 	// Construct the arguments to the PixelObservation constructor
 	var pythonPixelsOnly *python.PyObject
 	if pixelsOnly {
@@ -61,14 +48,8 @@ func NewPixelObservation(env *gogym.GymEnv, pixelsOnly bool,
 	)
 	defer newEnv.DecRef()
 	if newEnv == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		panic("pixelObservations: could not wrap environment")
+		panic(fmt.Sprintf("pixelObservations: could not wrap environment: "+
+			"%v", pythonError()))
 	}
 
 	// Create the new gogym Environment
@@ -94,3 +75,9 @@ func (p *PixelObservation) Close() {
 	// Close this environment
 	p.Environment.Close()
 }
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (p *PixelObservation) Info() map[string]interface{} {
+	return gogym.Info(p.Environment)
+}
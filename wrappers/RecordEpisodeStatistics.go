@@ -0,0 +1,172 @@
+package wrappers
+
+import (
+	"fmt"
+	"time"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// EpisodeKey is the key in the info dictionary at which
+// RecordEpisodeStatistics stores the statistics of an episode when it
+// ends, as in gym's RecordEpisodeStatistics wrapper. The value at this
+// key is a map[string]interface{} holding the episode return at "r"
+// (float64), the episode length at "l" (int), and the wall-clock time
+// of the episode in seconds at "t" (float64).
+const EpisodeKey = "episode"
+
+// RecordEpisodeStatistics wraps a gogym.Environment and keeps track of
+// the return, length, and wall-clock time of each episode. Statistics
+// of the most recent episodes are kept in a rolling window queue,
+// and the statistics of each episode are added to the info dictionary
+// at EpisodeKey on the step at which the episode ends.
+//
+// https://github.com/openai/gym/blob/master/gym/wrappers/record_episode_statistics.py
+type RecordEpisodeStatistics struct {
+	gogym.Environment
+	dequeSize int
+
+	// Statistics of the current episode
+	episodeStart  time.Time
+	episodeReturn float64
+	episodeLength int
+	episodeCount  int
+
+	// Statistics of the last dequeSize episodes
+	returnQueue []float64
+	lengthQueue []int
+	timeQueue   []time.Duration
+
+	// info is the info dictionary of the last step if the episode ended
+	// on that step, and nil otherwise
+	info map[string]interface{}
+}
+
+// NewRecordEpisodeStatistics returns a new RecordEpisodeStatistics
+// which keeps the statistics of the last dequeSize episodes of env
+func NewRecordEpisodeStatistics(env gogym.Environment,
+	dequeSize int) (gogym.Environment, error) {
+	if dequeSize <= 0 {
+		return nil, fmt.Errorf("newRecordEpisodeStatistics: dequeSize "+
+			"must be positive, got %v", dequeSize)
+	}
+
+	return &RecordEpisodeStatistics{
+		Environment:  env,
+		dequeSize:    dequeSize,
+		episodeStart: time.Now(),
+	}, nil
+}
+
+// Reset resets the environment and starts recording a new episode
+func (r *RecordEpisodeStatistics) Reset() (*mat.VecDense, error) {
+	obs, err := r.Environment.Reset()
+	if err != nil {
+		return nil, fmt.Errorf("reset: %v", err)
+	}
+
+	r.episodeStart = time.Now()
+	r.episodeReturn = 0
+	r.episodeLength = 0
+	r.info = nil
+	return obs, nil
+}
+
+// Step takes one environmental step given some action a, recording
+// the statistics of the episode if it ends
+func (r *RecordEpisodeStatistics) Step(a *mat.VecDense) (*mat.VecDense,
+	float64, bool, error) {
+	obs, reward, done, err := r.Environment.Step(a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+
+	r.episodeReturn += reward
+	r.episodeLength++
+	r.info = nil
+
+	if done {
+		elapsed := time.Since(r.episodeStart)
+		r.episodeCount++
+
+		r.returnQueue = append(r.returnQueue, r.episodeReturn)
+		r.lengthQueue = append(r.lengthQueue, r.episodeLength)
+		r.timeQueue = append(r.timeQueue, elapsed)
+		if len(r.returnQueue) > r.dequeSize {
+			r.returnQueue = r.returnQueue[1:]
+			r.lengthQueue = r.lengthQueue[1:]
+			r.timeQueue = r.timeQueue[1:]
+		}
+
+		// Copy the wrapped info dictionary, which should not be modified
+		r.info = make(map[string]interface{})
+		for key, value := range gogym.Info(r.Environment) {
+			r.info[key] = value
+		}
+		r.info[EpisodeKey] = map[string]interface{}{
+			"r": r.episodeReturn,
+			"l": r.episodeLength,
+			"t": elapsed.Seconds(),
+		}
+	}
+
+	return obs, reward, done, nil
+}
+
+// Info returns the info dictionary of the wrapped environment. If the
+// episode ended on the last step, then the statistics of the episode
+// are included at EpisodeKey.
+func (r *RecordEpisodeStatistics) Info() map[string]interface{} {
+	if r.info != nil {
+		return r.info
+	}
+	return gogym.Info(r.Environment)
+}
+
+// EpisodeReturn returns the return accumulated so far in the current
+// episode
+func (r *RecordEpisodeStatistics) EpisodeReturn() float64 {
+	return r.episodeReturn
+}
+
+// EpisodeLength returns the number of steps taken so far in the
+// current episode
+func (r *RecordEpisodeStatistics) EpisodeLength() int {
+	return r.episodeLength
+}
+
+// EpisodeCount returns the total number of episodes completed
+func (r *RecordEpisodeStatistics) EpisodeCount() int {
+	return r.episodeCount
+}
+
+// ReturnQueue returns the returns of the last completed episodes, up
+// to the deque size, from oldest to newest
+func (r *RecordEpisodeStatistics) ReturnQueue() []float64 {
+	queue := make([]float64, len(r.returnQueue))
+	copy(queue, r.returnQueue)
+	return queue
+}
+
+// LengthQueue returns the lengths of the last completed episodes, up
+// to the deque size, from oldest to newest
+func (r *RecordEpisodeStatistics) LengthQueue() []int {
+	queue := make([]int, len(r.lengthQueue))
+	copy(queue, r.lengthQueue)
+	return queue
+}
+
+// TimeQueue returns the wall-clock times of the last completed
+// episodes, up to the deque size, from oldest to newest
+func (r *RecordEpisodeStatistics) TimeQueue() []time.Duration {
+	queue := make([]time.Duration, len(r.timeQueue))
+	copy(queue, r.timeQueue)
+	return queue
+}
+
+// Env returns nil, since a RecordEpisodeStatistics has no Python environment
+func (r *RecordEpisodeStatistics) Env() *python.PyObject {
+	return nil
+}
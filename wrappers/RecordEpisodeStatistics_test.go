@@ -0,0 +1,82 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewRecordEpisodeStatistics(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 5)
+	goEnv.infoFunc = func(t int) map[string]interface{} {
+		return map[string]interface{}{"t": t}
+	}
+
+	env, err := wrappers.NewRecordEpisodeStatistics(goEnv, 2)
+	if err != nil {
+		t.Fatalf("newRecordEpisodeStatistics: %v", err)
+	}
+	stats := env.(*wrappers.RecordEpisodeStatistics)
+
+	// Run episodes of lengths 5, 3, and 5, truncating the second
+	action := mat.NewVecDense(1, []float64{0})
+	for episode := 0; episode < 3; episode++ {
+		if _, err = env.Reset(); err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+
+		for done := false; !done; {
+			_, _, done, err = env.Step(action)
+			if err != nil {
+				t.Fatalf("step: %v", err)
+			}
+
+			_, ok := gogym.Info(env)[wrappers.EpisodeKey]
+			if ok != done {
+				t.Errorf("info: episode statistics should only be in "+
+					"info at the end of an episode, have %v", gogym.Info(env))
+			}
+			if gogym.Info(env)["t"] != stats.EpisodeLength() {
+				t.Errorf("info: wrapped info not kept, have %v",
+					gogym.Info(env))
+			}
+
+			if episode == 1 && stats.EpisodeLength() == 3 {
+				break
+			}
+		}
+	}
+
+	if count := stats.EpisodeCount(); count != 2 {
+		t.Errorf("episodeCount: want(2) have(%v)", count)
+	}
+
+	// Only the last 2 episodes are kept
+	if lengths := stats.LengthQueue(); len(lengths) != 2 ||
+		lengths[0] != 5 || lengths[1] != 5 {
+		t.Errorf("lengthQueue: want([5 5]) have(%v)", lengths)
+	}
+	if returns := stats.ReturnQueue(); len(returns) != 2 ||
+		returns[0] != 5 || returns[1] != 5 {
+		t.Errorf("returnQueue: want([5 5]) have(%v)", returns)
+	}
+	if times := stats.TimeQueue(); len(times) != 2 {
+		t.Errorf("timeQueue: want 2 times, have %v", times)
+	}
+
+	episodeInfo := gogym.Info(env)[wrappers.EpisodeKey].(map[string]interface{})
+	if episodeInfo["r"] != 5.0 || episodeInfo["l"] != 5 {
+		t.Errorf("info: want return 5 and length 5, have %v", episodeInfo)
+	}
+
+	if _, err = wrappers.NewRecordEpisodeStatistics(goEnv, 0); err == nil {
+		t.Errorf("newRecordEpisodeStatistics: expected error for deque " +
+			"size 0")
+	}
+}
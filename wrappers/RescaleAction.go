@@ -7,30 +7,9 @@ import (
 	"github.com/samuelfneumann/gogym"
 )
 
-// wrappers.rescale_action Python module
+// gym.wrappers.rescale_action Python module, imported by importModule
 var rescaleActionModule *python.PyObject
 
-// init performs setup before running
-func init() {
-	// Create the gym.wrappers.rescale_action Python module
-	wrappersModule := python.PyImport_ImportModule("gym.wrappers." +
-		"rescale_action")
-	defer wrappersModule.DecRef()
-	if wrappersModule == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		panic("init: could not import gym.wrappers.rescale_action")
-	}
-	rescaleActionModule = python.PyImport_AddModule("gym.wrappers." +
-		"rescale_action")
-	wrappersModule.IncRef()
-}
-
 // RescaleAction wraps a gogym.Environment and rescales the continuous
 // action space of the environment to a range [a, b]. The action
 // space should be a BoxSpace.
@@ -47,21 +26,24 @@ type RescaleAction struct {
 // actions taken in env.
 func NewRescaleAction(env gogym.Environment, a, b float64) (gogym.Environment,
 	error) {
+	pyEnv, err := pythonEnv(env)
+	if err != nil {
+		return nil, fmt.Errorf("newRescaleAction: %v", err)
+	}
+	if err = importModule(&rescaleActionModule,
+		"gym.wrappers.rescale_action"); err != nil {
+		return nil, fmt.Errorf("newRescaleAction: %v", err)
+	}
+
 	// Call the RescaleAction constructor with the argument environment
 	low := python.PyFloat_FromDouble(a)
 	high := python.PyFloat_FromDouble(b)
-	newEnv := rescaleActionModule.CallMethodArgs("RescaleAction", env.Env(),
+	newEnv := rescaleActionModule.CallMethodArgs("RescaleAction", pyEnv,
 		low, high)
 	defer newEnv.DecRef()
 	if newEnv == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		return nil, fmt.Errorf("newRescaleAction: could not wrap environment")
+		return nil, fmt.Errorf("newRescaleAction: could not wrap "+
+			"environment: %v", pythonError())
 	}
 
 	// Create the new action space
@@ -122,3 +104,9 @@ func (r *RescaleAction) Close() {
 	// Close this environment
 	r.Environment.Close()
 }
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (r *RescaleAction) Info() map[string]interface{} {
+	return gogym.Info(r.Environment)
+}
@@ -0,0 +1,68 @@
+package wrappers
+
+import (
+	"fmt"
+
+	python "github.com/DataDog/go-python3"
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// RewardFunc transforms a reward of a wrapped environment into a
+// reward of the wrapper
+type RewardFunc func(reward float64) float64
+
+// RewardWrapper wraps a gogym.Environment and transforms each reward
+// returned by Step using a RewardFunc. It is the Go equivalent of
+// gym.RewardWrapper. New reward wrappers pass their RewardFunc to
+// NewRewardWrapper rather than overriding Reward, which Step calls
+// only on the RewardWrapper itself.
+//
+// https://github.com/openai/gym/blob/master/gym/core.py
+type RewardWrapper struct {
+	gogym.Environment
+	reward RewardFunc
+}
+
+// NewRewardWrapper returns a new RewardWrapper which transforms the
+// rewards of env using f
+func NewRewardWrapper(env gogym.Environment, f RewardFunc) (*RewardWrapper,
+	error) {
+	if f == nil {
+		return nil, fmt.Errorf("newRewardWrapper: reward function cannot " +
+			"be nil")
+	}
+
+	return &RewardWrapper{
+		Environment: env,
+		reward:      f,
+	}, nil
+}
+
+// Reward returns the reward of the wrapper corresponding to reward r
+// of the wrapped environment
+func (w *RewardWrapper) Reward(r float64) float64 {
+	return w.reward(r)
+}
+
+// Step takes one environmental step given some action a, transforming
+// the reward
+func (w *RewardWrapper) Step(a *mat.VecDense) (*mat.VecDense, float64,
+	bool, error) {
+	obs, reward, done, err := w.Environment.Step(a)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("step: %v", err)
+	}
+	return obs, w.Reward(reward), done, nil
+}
+
+// Env returns nil, since a RewardWrapper has no Python environment
+func (w *RewardWrapper) Env() *python.PyObject {
+	return nil
+}
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (w *RewardWrapper) Info() map[string]interface{} {
+	return gogym.Info(w.Environment)
+}
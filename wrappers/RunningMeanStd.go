@@ -0,0 +1,187 @@
+package wrappers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// initialCount is the initial count of a RunningMeanStd, which avoids
+// division by zero before any samples have been seen
+const initialCount = 1e-4
+
+// RunningMeanStd tracks the running mean and variance of vectors using
+// the parallel algorithm of Chan et al., which generalizes Welford's
+// algorithm to batches of samples.
+//
+// https://en.wikipedia.org/wiki/Algorithms_for_calculating_variance
+type RunningMeanStd struct {
+	mean     *mat.VecDense
+	variance *mat.VecDense
+	count    float64
+}
+
+// runningMeanStdJSON is the serialized form of a RunningMeanStd
+type runningMeanStdJSON struct {
+	Mean     []float64 `json:"mean"`
+	Variance []float64 `json:"variance"`
+	Count    float64   `json:"count"`
+}
+
+// NewRunningMeanStd returns a new RunningMeanStd for vectors of
+// dimension dim, with initial mean 0 and variance 1
+func NewRunningMeanStd(dim int) (*RunningMeanStd, error) {
+	if dim <= 0 {
+		return nil, fmt.Errorf("newRunningMeanStd: dim must be positive, "+
+			"got %v", dim)
+	}
+
+	variance := make([]float64, dim)
+	for i := range variance {
+		variance[i] = 1.0
+	}
+
+	return &RunningMeanStd{
+		mean:     mat.NewVecDense(dim, nil),
+		variance: mat.NewVecDense(dim, variance),
+		count:    initialCount,
+	}, nil
+}
+
+// Len returns the dimension of the tracked vectors
+func (r *RunningMeanStd) Len() int {
+	return r.mean.Len()
+}
+
+// Mean returns the running mean
+func (r *RunningMeanStd) Mean() *mat.VecDense {
+	return mat.VecDenseCopyOf(r.mean)
+}
+
+// Var returns the running variance
+func (r *RunningMeanStd) Var() *mat.VecDense {
+	return mat.VecDenseCopyOf(r.variance)
+}
+
+// Count returns the number of samples seen
+func (r *RunningMeanStd) Count() float64 {
+	return r.count
+}
+
+// Update updates the running statistics with a single sample x
+func (r *RunningMeanStd) Update(x *mat.VecDense) error {
+	if x.Len() != r.Len() {
+		return fmt.Errorf("update: sample should have length %v but got %v",
+			r.Len(), x.Len())
+	}
+
+	r.update(x, mat.NewVecDense(r.Len(), nil), 1)
+	return nil
+}
+
+// UpdateBatch updates the running statistics with a batch of samples,
+// where each row of batch is a single sample, for example one
+// observation from each of a number of environments
+func (r *RunningMeanStd) UpdateBatch(batch *mat.Dense) error {
+	rows, cols := batch.Dims()
+	if cols != r.Len() {
+		return fmt.Errorf("updateBatch: samples should have length %v but "+
+			"got %v", r.Len(), cols)
+	}
+
+	mean := mat.NewVecDense(cols, nil)
+	variance := mat.NewVecDense(cols, nil)
+	for j := 0; j < cols; j++ {
+		col := mat.Col(nil, j, batch)
+
+		var sum float64
+		for _, x := range col {
+			sum += x
+		}
+		m := sum / float64(rows)
+		mean.SetVec(j, m)
+
+		var sqSum float64
+		for _, x := range col {
+			sqSum += (x - m) * (x - m)
+		}
+		variance.SetVec(j, sqSum/float64(rows))
+	}
+
+	r.update(mean, variance, float64(rows))
+	return nil
+}
+
+// update combines the running statistics with the statistics of a
+// batch of count samples
+func (r *RunningMeanStd) update(mean, variance *mat.VecDense, count float64) {
+	total := r.count + count
+	for i := 0; i < r.Len(); i++ {
+		delta := mean.AtVec(i) - r.mean.AtVec(i)
+
+		m2 := r.variance.AtVec(i)*r.count + variance.AtVec(i)*count +
+			delta*delta*r.count*count/total
+
+		r.mean.SetVec(i, r.mean.AtVec(i)+delta*count/total)
+		r.variance.SetVec(i, m2/total)
+	}
+	r.count = total
+}
+
+// Normalize returns x normalized by the running statistics, that is
+// (x - mean) / sqrt(var + epsilon)
+func (r *RunningMeanStd) Normalize(x *mat.VecDense,
+	epsilon float64) (*mat.VecDense, error) {
+	if x.Len() != r.Len() {
+		return nil, fmt.Errorf("normalize: input should have length %v but "+
+			"got %v", r.Len(), x.Len())
+	}
+
+	normalized := mat.NewVecDense(r.Len(), nil)
+	for i := 0; i < r.Len(); i++ {
+		normalized.SetVec(i, (x.AtVec(i)-r.mean.AtVec(i))/
+			math.Sqrt(r.variance.AtVec(i)+epsilon))
+	}
+	return normalized, nil
+}
+
+// Save writes the running statistics to w as JSON
+func (r *RunningMeanStd) Save(w io.Writer) error {
+	err := json.NewEncoder(w).Encode(runningMeanStdJSON{
+		Mean:     r.mean.RawVector().Data,
+		Variance: r.variance.RawVector().Data,
+		Count:    r.count,
+	})
+	if err != nil {
+		return fmt.Errorf("save: %v", err)
+	}
+	return nil
+}
+
+// Load reads running statistics written by Save from rd, replacing
+// the current statistics. The loaded statistics must have the same
+// dimension as r.
+func (r *RunningMeanStd) Load(rd io.Reader) error {
+	var stats runningMeanStdJSON
+	if err := json.NewDecoder(rd).Decode(&stats); err != nil {
+		return fmt.Errorf("load: %v", err)
+	}
+
+	if len(stats.Mean) != r.Len() || len(stats.Variance) != r.Len() {
+		return fmt.Errorf("load: statistics should have length %v but got "+
+			"mean of length %v and variance of length %v", r.Len(),
+			len(stats.Mean), len(stats.Variance))
+	}
+	if stats.Count <= 0 {
+		return fmt.Errorf("load: count must be positive, got %v",
+			stats.Count)
+	}
+
+	r.mean = mat.NewVecDense(r.Len(), stats.Mean)
+	r.variance = mat.NewVecDense(r.Len(), stats.Variance)
+	r.count = stats.Count
+	return nil
+}
@@ -0,0 +1,68 @@
+package wrappers
+
+import (
+	"fmt"
+
+	"github.com/samuelfneumann/gogym"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// StickyAction wraps a gogym.Environment and, on each step, takes the
+// previous action again instead of the given action with probability
+// p, as with the sticky actions of the Arcade Learning Environment v5.
+// On the first step of each episode, the given action is always taken.
+type StickyAction struct {
+	*ActionWrapper
+	rng *rand.Rand
+	p   float64
+
+	// previous is the previous action taken in the wrapped environment,
+	// or nil if no action has been taken since the last reset
+	previous *mat.VecDense
+}
+
+// NewStickyAction returns a new StickyAction which repeats the previous
+// action taken in env with probability p
+func NewStickyAction(env gogym.Environment, p float64) (gogym.Environment,
+	error) {
+	if p < 0 || p > 1 {
+		return nil, fmt.Errorf("newStickyAction: p must be in [0, 1], "+
+			"got %v", p)
+	}
+
+	src := rand.NewSource(gogym.NewSeed())
+	s := &StickyAction{
+		rng: rand.New(src),
+		p:   p,
+	}
+
+	var err error
+	s.ActionWrapper, err = NewActionWrapper(env, nil, s.action)
+	if err != nil {
+		return nil, fmt.Errorf("newStickyAction: %v", err)
+	}
+	return s, nil
+}
+
+// action returns the previous action with probability p, and a
+// otherwise
+func (s *StickyAction) action(a *mat.VecDense) (*mat.VecDense, error) {
+	if s.previous == nil || s.rng.Float64() >= s.p {
+		s.previous = mat.VecDenseCopyOf(a)
+	}
+	return s.previous, nil
+}
+
+// Seed seeds the wrapped environment and the random number generator
+// which determines when actions stick
+func (s *StickyAction) Seed(seed int) ([]int, error) {
+	s.rng.Seed(uint64(seed))
+	return s.Environment.Seed(seed)
+}
+
+// Reset resets the environment and forgets the previous action
+func (s *StickyAction) Reset() (*mat.VecDense, error) {
+	s.previous = nil
+	return s.ActionWrapper.Reset()
+}
@@ -0,0 +1,92 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewStickyAction(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+
+	// takenActions returns the actions taken in the wrapped environment
+	// when alternating between actions 0 and 1 with stickiness p. The
+	// wrapper is seeded with seed if it is non-negative.
+	takenActions := func(p float64, seed int) []float64 {
+		goEnv := newGoEnvironment(t, actionSpace, 1000)
+		env, err := wrappers.NewStickyAction(goEnv, p)
+		if err != nil {
+			t.Fatalf("newStickyAction: %v", err)
+		}
+		if seed >= 0 {
+			env.Seed(seed)
+		}
+		if _, err = env.Reset(); err != nil {
+			t.Fatalf("reset: %v", err)
+		}
+
+		for i := 0; i < 1000; i++ {
+			action := mat.NewVecDense(1, []float64{float64(i % 2)})
+			if _, _, _, err = env.Step(action); err != nil {
+				t.Fatalf("step: %v", err)
+			}
+		}
+
+		taken := make([]float64, len(goEnv.actions))
+		for i := range taken {
+			taken[i] = goEnv.actions[i].AtVec(0)
+		}
+		return taken
+	}
+
+	// Actions never stick with p = 0
+	for i, action := range takenActions(0, 1) {
+		if action != float64(i%2) {
+			t.Fatalf("step: action %v taken at step %v with p = 0", action, i)
+		}
+	}
+
+	// Actions stick about p of the time
+	taken := takenActions(0.25, 1)
+	if taken[0] != 0 {
+		t.Errorf("step: first action of episode should not stick")
+	}
+	sticks := 0
+	for i, action := range taken {
+		if action != float64(i%2) {
+			sticks++
+		}
+	}
+	if sticks < 150 || sticks > 350 {
+		t.Errorf("step: want about 250 sticky actions, have %v", sticks)
+	}
+
+	// Sticky actions are reproducible with a seed
+	again := takenActions(0.25, 1)
+	for i := range taken {
+		if taken[i] != again[i] {
+			t.Fatalf("seed: sticky actions not reproducible")
+		}
+	}
+
+	// Sticky actions are reproducible with the package seed
+	gogym.SetSeed(7)
+	taken = takenActions(0.25, -1)
+	gogym.SetSeed(7)
+	again = takenActions(0.25, -1)
+	for i := range taken {
+		if taken[i] != again[i] {
+			t.Fatalf("setSeed: sticky actions not reproducible")
+		}
+	}
+
+	if _, err = wrappers.NewStickyAction(newGoEnvironment(t, actionSpace,
+		10), 1.5); err == nil {
+		t.Errorf("newStickyAction: expected error for p > 1")
+	}
+}
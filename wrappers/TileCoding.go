@@ -0,0 +1,235 @@
+package wrappers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/samuelfneumann/gogym"
+	"gonum.org/v1/gonum/mat"
+)
+
+// TileCoding wraps a gogym.Environment with a bounded BoxSpace
+// observation space and converts each observation into tile-coded
+// features for linear function approximation.
+//
+// Each of the tilings partitions the bounds of the observation space
+// into a grid of tiles, with tilesPerDim tiles of equal width along
+// each dimension. Tilings are offset from each other by a fraction of
+// the tile width, using the asymmetric displacement vector (1, 3, 5,
+// ...) so that tilings are not offset along the diagonal. To cover the
+// bounds after the offset, each tiling has one extra tile along each
+// dimension. Exactly one tile is active in each tiling.
+//
+// Observations are clipped to the bounds of the observation space
+// before they are tile coded. If a memory size is given, then the
+// tiles are hashed into that many features, and collisions between
+// tiles are possible. Otherwise, each tile is a unique feature.
+//
+// Observations of the wrapper are either the indices of the active
+// features, one per tiling in order of the tilings, or a binary vector
+// with a 1 at each active feature. The observation space is a BoxSpace
+// of data type Int64 over [0, Features()-1] in the first case, and a
+// BoxSpace over [0, 1] in the second case.
+//
+// http://incompleteideas.net/tiles/tiles3.html
+type TileCoding struct {
+	*ObservationWrapper
+
+	tilings     int
+	tilesPerDim []int
+	memorySize  int
+	binary      bool
+
+	// Bounds and tile widths of the wrapped observation space
+	low, high, width []float64
+
+	// features is the number of features, which is the number of tiles
+	// if tiles are not hashed
+	features int
+}
+
+// NewTileCoding returns a new TileCoding which tile codes the
+// observations of env using the given number of tilings. The argument
+// tilesPerDim holds the number of tiles along each dimension of the
+// observations, or a single number of tiles used for all dimensions.
+// If memorySize is positive, then tiles are hashed into memorySize
+// features. If binary is true, then observations are binary feature
+// vectors, and otherwise they are the indices of the active features.
+func NewTileCoding(env gogym.Environment, tilings int, tilesPerDim []int,
+	memorySize int, binary bool) (gogym.Environment, error) {
+	box, ok := env.ObservationSpace().(*gogym.BoxSpace)
+	if !ok {
+		return nil, fmt.Errorf("newTileCoding: observation space should "+
+			"be a BoxSpace but got %T", env.ObservationSpace())
+	}
+	if tilings <= 0 {
+		return nil, fmt.Errorf("newTileCoding: tilings must be positive, "+
+			"got %v", tilings)
+	}
+	if memorySize < 0 {
+		return nil, fmt.Errorf("newTileCoding: memorySize must be "+
+			"non-negative, got %v", memorySize)
+	}
+
+	low := box.Low()[0].RawVector().Data
+	high := box.High()[0].RawVector().Data
+	dims := len(low)
+
+	if len(tilesPerDim) == 1 && dims > 1 {
+		tiles := make([]int, dims)
+		for i := range tiles {
+			tiles[i] = tilesPerDim[0]
+		}
+		tilesPerDim = tiles
+	}
+	if len(tilesPerDim) != dims {
+		return nil, fmt.Errorf("newTileCoding: tilesPerDim should have "+
+			"length 1 or %v but got %v", dims, len(tilesPerDim))
+	}
+
+	t := &TileCoding{
+		tilings:     tilings,
+		tilesPerDim: make([]int, dims),
+		memorySize:  memorySize,
+		binary:      binary,
+		low:         make([]float64, dims),
+		high:        make([]float64, dims),
+		width:       make([]float64, dims),
+	}
+	copy(t.tilesPerDim, tilesPerDim)
+	copy(t.low, low)
+	copy(t.high, high)
+
+	tilesPerTiling := 1
+	for i, tiles := range tilesPerDim {
+		if tiles <= 0 {
+			return nil, fmt.Errorf("newTileCoding: tiles must be positive, "+
+				"got %v", tiles)
+		}
+		if math.IsInf(low[i], 0) || math.IsInf(high[i], 0) {
+			return nil, fmt.Errorf("newTileCoding: cannot tile code "+
+				"unbounded observation dimension %v", i)
+		}
+		t.width[i] = (high[i] - low[i]) / float64(tiles)
+		tilesPerTiling *= tiles + 1
+	}
+
+	t.features = tilings * tilesPerTiling
+	if memorySize > 0 {
+		t.features = memorySize
+	}
+
+	// Create the observation space
+	size, dtype, upper := tilings, gogym.Int64, float64(t.features-1)
+	if binary {
+		size, dtype, upper = t.features, gogym.Float64, 1.0
+	}
+	lowBound := make([]float64, size)
+	highBound := make([]float64, size)
+	for i := range highBound {
+		highBound[i] = upper
+	}
+	space, err := gogym.NewTypedBox(lowBound, highBound, nil, dtype)
+	if err != nil {
+		return nil, fmt.Errorf("newTileCoding: could not create "+
+			"observation space: %v", err)
+	}
+
+	t.ObservationWrapper, err = NewObservationWrapper(env, space,
+		t.observation)
+	if err != nil {
+		return nil, fmt.Errorf("newTileCoding: %v", err)
+	}
+	return t, nil
+}
+
+// observation returns the tile-coded features of obs
+func (t *TileCoding) observation(obs *mat.VecDense) (*mat.VecDense,
+	error) {
+	tiles, err := t.Tiles(obs)
+	if err != nil {
+		return nil, fmt.Errorf("observation: %v", err)
+	}
+
+	if !t.binary {
+		features := make([]float64, len(tiles))
+		for i, tile := range tiles {
+			features[i] = float64(tile)
+		}
+		return mat.NewVecDense(len(features), features), nil
+	}
+
+	features := mat.NewVecDense(t.features, nil)
+	for _, tile := range tiles {
+		features.SetVec(tile, 1)
+	}
+	return features, nil
+}
+
+// Tiles returns the indices of the features active for an observation
+// of the wrapped environment, one per tiling in order of the tilings
+func (t *TileCoding) Tiles(obs *mat.VecDense) ([]int, error) {
+	if obs.Len() != len(t.low) {
+		return nil, fmt.Errorf("tiles: observation should have %v "+
+			"elements but got %v", len(t.low), obs.Len())
+	}
+
+	tiles := make([]int, t.tilings)
+	coords := make([]int, len(t.low))
+	for tiling := range tiles {
+		for i := range coords {
+			x := math.Max(t.low[i], math.Min(t.high[i], obs.AtVec(i)))
+
+			// Offset each tiling by a fraction of the tile width using
+			// the displacement vector (1, 3, 5, ...)
+			displacement := (tiling * (2*i + 1)) % t.tilings
+			offset := float64(displacement) / float64(t.tilings)
+
+			coord := 0
+			if t.width[i] > 0 {
+				coord = int(math.Floor((x-t.low[i])/t.width[i] + offset))
+			}
+			if coord > t.tilesPerDim[i] {
+				coord = t.tilesPerDim[i]
+			}
+			coords[i] = coord
+		}
+		tiles[tiling] = t.index(tiling, coords)
+	}
+	return tiles, nil
+}
+
+// index returns the index of the feature of the tile at coords in the
+// given tiling
+func (t *TileCoding) index(tiling int, coords []int) int {
+	if t.memorySize > 0 {
+		hash := fnv.New64a()
+		buf := make([]byte, 8)
+		binary.LittleEndian.PutUint64(buf, uint64(tiling))
+		hash.Write(buf)
+		for _, coord := range coords {
+			binary.LittleEndian.PutUint64(buf, uint64(coord))
+			hash.Write(buf)
+		}
+		return int(hash.Sum64() % uint64(t.memorySize))
+	}
+
+	index := tiling
+	for i, coord := range coords {
+		index = index*(t.tilesPerDim[i]+1) + coord
+	}
+	return index
+}
+
+// Tilings returns the number of tilings
+func (t *TileCoding) Tilings() int {
+	return t.tilings
+}
+
+// Features returns the total number of features, which is the memory
+// size if tiles are hashed
+func (t *TileCoding) Features() int {
+	return t.features
+}
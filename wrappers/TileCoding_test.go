@@ -0,0 +1,125 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewTileCoding(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	// Observations are in [0, 1000] x [-1000, 1000], so tiles have width
+	// 500 x 1000 with 2 tiles per dimension
+	env, err := wrappers.NewTileCoding(goEnv, 2, []int{2}, 0, false)
+	if err != nil {
+		t.Fatalf("newTileCoding: %v", err)
+	}
+	tc := env.(*wrappers.TileCoding)
+	if tc.Features() != 18 {
+		t.Errorf("features: want(18) have(%v)", tc.Features())
+	}
+
+	space := env.ObservationSpace().(*gogym.BoxSpace)
+	if space.DType() != gogym.Int64 || space.Low()[0].Len() != 2 ||
+		space.High()[0].AtVec(0) != 17 {
+		t.Errorf("observationSpace: want Int64 bounds([0 0] [17 17]) "+
+			"have %v (%v %v)", space.DType(), space.Low()[0].RawVector().Data,
+			space.High()[0].RawVector().Data)
+	}
+
+	// The second tiling is offset by half a tile in the first dimension
+	// and (3 mod 2) / 2 of a tile in the second dimension
+	tests := []struct {
+		obs   []float64
+		tiles []int
+	}{
+		{[]float64{0, -1000}, []int{0, 9 + 0}},
+		{[]float64{0, 0}, []int{1, 9 + 1}},
+		{[]float64{300, 0}, []int{1, 9 + 3 + 1}},
+		{[]float64{600, 999}, []int{3 + 1, 9 + 3 + 2}},
+		{[]float64{2000, 2000}, []int{6 + 2, 9 + 6 + 2}},
+	}
+	for _, test := range tests {
+		tiles, err := tc.Tiles(mat.NewVecDense(2, test.obs))
+		if err != nil {
+			t.Fatalf("tiles: %v", err)
+		}
+		for i := range tiles {
+			if tiles[i] != test.tiles[i] {
+				t.Errorf("tiles: want(%v) have(%v) for observation %v",
+					test.tiles, tiles, test.obs)
+				break
+			}
+		}
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if want := []float64{1, 10}; !floats.Equal(obs.RawVector().Data, want) {
+		t.Errorf("reset: want(%v) have(%v)", want, obs.RawVector().Data)
+	}
+	if !space.Contains(obs) {
+		t.Errorf("reset: observation %v not in observation space",
+			obs.RawVector().Data)
+	}
+
+	// Binary features with hashing
+	env, err = wrappers.NewTileCoding(goEnv, 4, []int{3, 5}, 64, true)
+	if err != nil {
+		t.Fatalf("newTileCoding: %v", err)
+	}
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		obs, _, _, err := env.Step(mat.NewVecDense(1, []float64{1}))
+		if err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		if obs.Len() != 64 || !env.ObservationSpace().Contains(obs) {
+			t.Errorf("step: observation %v not in observation space",
+				obs.RawVector().Data)
+		}
+		if sum := floats.Sum(obs.RawVector().Data); sum < 1 || sum > 4 {
+			t.Errorf("step: want between 1 and 4 active features have(%v)",
+				sum)
+		}
+	}
+
+	if _, err = wrappers.NewTileCoding(goEnv, 0, []int{2}, 0, false); err ==
+		nil {
+		t.Errorf("newTileCoding: expected error for 0 tilings")
+	}
+}
+
+func TestTileCodingMountainCar(t *testing.T) {
+	env, err := gogym.Make("MountainCar-v0")
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+	defer env.Close()
+
+	env, err = wrappers.NewTileCoding(env, 8, []int{8}, 4096, false)
+	if err != nil {
+		t.Fatalf("newTileCoding: %v", err)
+	}
+
+	obs, err := env.Reset()
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if obs.Len() != 8 || !env.ObservationSpace().Contains(obs) {
+		t.Errorf("reset: observation %v not in observation space",
+			obs.RawVector().Data)
+	}
+}
@@ -7,30 +7,9 @@ import (
 	"github.com/samuelfneumann/gogym"
 )
 
-// wrappers.rescale_action Python module
+// gym.wrappers.time_limit Python module, imported by importModule
 var timeLimitModule *python.PyObject
 
-// init performs setup before running
-func init() {
-	// Create the gym.wrappers.time_limit Python module
-	wrappersModule := python.PyImport_ImportModule("gym.wrappers." +
-		"time_limit")
-	defer wrappersModule.DecRef()
-	if wrappersModule == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		panic("init: could not import gym.wrappers.time_limit")
-	}
-	timeLimitModule = python.PyImport_AddModule("gym.wrappers." +
-		"time_limit")
-	wrappersModule.IncRef()
-}
-
 // TimeLimit wraps a gogym.Environment and provides for it a limit on
 // the time steps. Note that all environments in OpenAI Gym have
 // default time limits, and that if a TimeLimit wrapper is used, the
@@ -68,15 +47,9 @@ func AlterDefaultTimeLimit(env gogym.Environment,
 	pyEnv := embedded.GetAttrString("env")
 	defer pyEnv.DecRef()
 	if pyEnv == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
 		return nil, fmt.Errorf("timeLimitOfEmbedded: no embedded environment "+
-			"named 'env' in Python object for %v environment", env.Name())
+			"named 'env' in Python object for %v environment: %v", env.Name(),
+			pythonError())
 	}
 
 	newEnv := gogym.New(pyEnv, env.Name(), env.ContinuousAction(),
@@ -97,20 +70,23 @@ func NewTimeLimit(env gogym.Environment,
 	if maxEpisodeSteps <= 0 {
 		return nil, fmt.Errorf("newTimeLimit: maxEpisodeSteps must be positive")
 	}
+	pyEnv, err := pythonEnv(env)
+	if err != nil {
+		return nil, fmt.Errorf("newTimeLimit: %v", err)
+	}
+	if err = importModule(&timeLimitModule,
+		"gym.wrappers.time_limit"); err != nil {
+		return nil, fmt.Errorf("newTimeLimit: %v", err)
+	}
+
 	// Call the TimeLimit constructor with the argument environment
 	pyCutoff := python.PyLong_FromGoInt(int(maxEpisodeSteps))
-	newEnv := timeLimitModule.CallMethodArgs("TimeLimit", env.Env(), pyCutoff)
+	newEnv := timeLimitModule.CallMethodArgs("TimeLimit", pyEnv, pyCutoff)
 	defer newEnv.DecRef()
 
 	if newEnv == nil {
-		if python.PyErr_Occurred() != nil {
-			fmt.Println()
-			fmt.Println("========== Python Error ==========")
-			python.PyErr_Print()
-			fmt.Println("==================================")
-			fmt.Println()
-		}
-		return nil, fmt.Errorf("newTimeLimit: could not wrap environment")
+		return nil, fmt.Errorf("newTimeLimit: could not wrap environment: %v",
+			pythonError())
 	}
 
 	// Create the new gogym Environment
@@ -137,3 +113,9 @@ func (t *TimeLimit) Close() {
 	// Close this environment
 	t.Environment.Close()
 }
+
+// Info returns the info dictionary of the wrapped environment's last
+// step or reset
+func (t *TimeLimit) Info() map[string]interface{} {
+	return gogym.Info(t.Environment)
+}
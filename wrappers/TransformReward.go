@@ -0,0 +1,26 @@
+package wrappers
+
+import (
+	"fmt"
+
+	"github.com/samuelfneumann/gogym"
+)
+
+// TransformReward wraps a gogym.Environment and transforms each reward
+// using a Go function.
+//
+// https://github.com/openai/gym/blob/master/gym/wrappers/transform_reward.py
+type TransformReward struct {
+	*RewardWrapper
+}
+
+// NewTransformReward returns a new TransformReward which transforms
+// the rewards of env using f
+func NewTransformReward(env gogym.Environment,
+	f RewardFunc) (gogym.Environment, error) {
+	rewardWrapper, err := NewRewardWrapper(env, f)
+	if err != nil {
+		return nil, fmt.Errorf("newTransformReward: %v", err)
+	}
+	return &TransformReward{rewardWrapper}, nil
+}
@@ -0,0 +1,34 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/samuelfneumann/gogym"
+	"github.com/samuelfneumann/gogym/wrappers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewTransformReward(t *testing.T) {
+	actionSpace, err := gogym.NewDiscrete(2)
+	if err != nil {
+		t.Fatalf("newDiscrete: %v", err)
+	}
+	goEnv := newGoEnvironment(t, actionSpace, 10)
+
+	env, err := wrappers.NewTransformReward(goEnv,
+		func(reward float64) float64 { return 0.01 * reward })
+	if err != nil {
+		t.Fatalf("newTransformReward: %v", err)
+	}
+	if _, err = env.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	_, reward, _, err := env.Step(mat.NewVecDense(1, []float64{0}))
+	if err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if reward != 0.01 {
+		t.Errorf("step: want reward(0.01) have(%v)", reward)
+	}
+}